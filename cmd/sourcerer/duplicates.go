@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/analyzer"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+)
+
+// runDuplicates brings the workspace's index up to date & reports clusters of duplicated code
+func runDuplicates(args []string) {
+	flags := flag.NewFlagSet("duplicates", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sourcerer duplicates [flags] [dir]")
+		flags.PrintDefaults()
+	}
+	threshold := flags.Float64("threshold", index.DefaultDuplicateThreshold, "minimum similarity of near-duplicates")
+	exact := flags.Bool("exact", false, "only report code that's identical ignoring comments, whitespace & names")
+	minTokens := flags.Int("min-tokens", index.DefaultDuplicateMinTokens, "ignore chunks smaller than this")
+	limit := flags.Int("limit", 0, "maximum number of clusters, largest first (0 for all)")
+	flags.Parse(args)
+
+	if flags.NArg() > 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	workspaceRoot := getWorkspaceRoot()
+
+	opts := analyzerOptions(workspaceRoot)
+	opts.Static = true
+
+	a, err := analyzer.New(ctx, workspaceRoot, opts)
+	if err != nil {
+		log.Fatalf("Failed to create analyzer: %v", err)
+	}
+	defer a.Close()
+
+	a.IndexWorkspace(ctx)
+
+	clusters, err := a.FindDuplicates(ctx, index.DuplicateOptions{
+		Threshold: float32(*threshold),
+		MinTokens: *minTokens,
+		Dir:       flags.Arg(0),
+		Exact:     *exact,
+	})
+	if err != nil {
+		log.Fatalf("Duplicate detection failed: %v", err)
+	}
+
+	if len(clusters) == 0 {
+		fmt.Println("No duplicates found.")
+		return
+	}
+
+	if *limit > 0 {
+		clusters = clusters[:min(len(clusters), *limit)]
+	}
+
+	fmt.Print(index.FormatDuplicates(clusters))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/philippgille/chromem-go"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/eval"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/reranker"
+)
+
+// runEval scores retrieval on a dataset, exiting non-zero if it's below the dataset's thresholds
+func runEval(args []string) {
+	flags := flag.NewFlagSet("eval", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sourcerer eval [flags] <dataset.yaml>")
+		flags.PrintDefaults()
+	}
+	k := flags.Int("k", 0, "rank cutoff, overrides the dataset's")
+	embedder := flags.String("embedder", "hashing", "embedder to evaluate: hashing (offline) or openai")
+	rerank := flags.String("reranker", "", "reranker to evaluate: heuristic, or the URL of a rerank endpoint")
+	expand := flags.Bool("expand", false, "expand queries with related identifiers from the workspace")
+	verbose := flags.Bool("v", false, "list the expected chunks missed by each query")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	opts := eval.Options{K: *k, Expand: *expand}
+	switch *embedder {
+	case "hashing":
+	case "openai":
+		opts.Embedder = chromem.NewEmbeddingFuncDefault()
+	default:
+		log.Fatalf("Unknown embedder %q, expected hashing or openai", *embedder)
+	}
+
+	switch {
+	case *rerank == "heuristic":
+		opts.Reranker = func(workspaceRoot string) reranker.Reranker {
+			return reranker.NewHeuristic(workspaceRoot)
+		}
+	case *rerank != "":
+		opts.Reranker = func(string) reranker.Reranker {
+			return newHTTPReranker(*rerank)
+		}
+	}
+
+	report, err := eval.Run(context.Background(), flags.Arg(0), opts)
+	if err != nil {
+		log.Fatalf("Evaluation failed: %v", err)
+	}
+
+	fmt.Print(report.String(*verbose))
+
+	failures := report.Failures()
+	if len(failures) > 0 {
+		fmt.Printf("below thresholds: %s\n", strings.Join(failures, ", "))
+		os.Exit(1)
+	}
+}
@@ -3,11 +3,15 @@ package main
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	_ "embed"
 
+	"github.com/st3v3nmw/sourcerer-mcp/internal/analyzer"
 	"github.com/st3v3nmw/sourcerer-mcp/internal/mcp"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/reranker"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/summarizer"
 )
 
 //go:embed VERSION
@@ -16,12 +20,24 @@ var Version string
 func main() {
 	Version = strings.TrimSpace(Version)
 
-	workspaceRoot := os.Getenv("SOURCERER_WORKSPACE_ROOT")
-	if workspaceRoot == "" {
-		workspaceRoot = "."
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "eval":
+			runEval(os.Args[2:])
+			return
+		case "duplicates":
+			runDuplicates(os.Args[2:])
+			return
+		}
+	}
+
+	workspaceRoot := getWorkspaceRoot()
+	opts := mcp.Options{
+		Options:   analyzerOptions(workspaceRoot),
+		MaxTokens: envInt("SOURCERER_MAX_TOKENS"),
 	}
 
-	server, err := mcp.NewServer(workspaceRoot, Version)
+	server, err := mcp.NewServer(workspaceRoot, Version, opts)
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
 	}
@@ -32,3 +48,62 @@ func main() {
 		log.Fatalf("Server error: %v", err)
 	}
 }
+
+// getWorkspaceRoot returns the workspace to serve, defaulting to the current directory
+func getWorkspaceRoot() string {
+	workspaceRoot := os.Getenv("SOURCERER_WORKSPACE_ROOT")
+	if workspaceRoot == "" {
+		workspaceRoot = "."
+	}
+
+	return workspaceRoot
+}
+
+// analyzerOptions configures the analyzer from the environment
+func analyzerOptions(workspaceRoot string) analyzer.Options {
+	opts := analyzer.Options{
+		MaxChunkTokens: envInt("SOURCERER_MAX_CHUNK_TOKENS"),
+	}
+
+	summarizerURL := os.Getenv("SOURCERER_SUMMARIZER_URL")
+	if summarizerURL != "" {
+		opts.Summarizer = summarizer.NewOpenAI(
+			summarizerURL,
+			os.Getenv("SOURCERER_SUMMARIZER_MODEL"),
+			os.Getenv("SOURCERER_SUMMARIZER_API_KEY"),
+		)
+	}
+
+	switch rerankerURL := os.Getenv("SOURCERER_RERANKER_URL"); {
+	case rerankerURL != "":
+		opts.Reranker = newHTTPReranker(rerankerURL)
+	case os.Getenv("SOURCERER_RERANKER") == "heuristic":
+		opts.Reranker = reranker.NewHeuristic(workspaceRoot)
+	}
+
+	return opts
+}
+
+// newHTTPReranker creates a reranker for the endpoint at url, configured by the environment
+func newHTTPReranker(url string) *reranker.HTTP {
+	return reranker.NewHTTP(
+		url,
+		os.Getenv("SOURCERER_RERANKER_MODEL"),
+		os.Getenv("SOURCERER_RERANKER_API_KEY"),
+	)
+}
+
+// envInt reads an integer environment variable, returning 0 if it's unset
+func envInt(name string) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return 0
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", name, err)
+	}
+
+	return value
+}
@@ -3,39 +3,83 @@ package analyzer
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/st3v3nmw/sourcerer-mcp/internal/fs"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/git"
 	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
 	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/reranker"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/summarizer"
 )
 
+// Options configures an Analyzer, zero values fall back to defaults
+type Options struct {
+	Index          index.Options
+	MaxChunkTokens int                   // chunks above this size are split into parts for embedding
+	Summarizer     summarizer.Summarizer // optional, generates descriptions of named chunks
+	Reranker       reranker.Reranker     // optional, reorders search candidates
+	// Static analyzers don't watch the workspace, index it in the background, or record
+	// manifests, e.g., for evaluations. Call IndexWorkspace to index the workspace.
+	Static bool
+}
+
 type Analyzer struct {
 	workspaceRoot string
+	opts          Options
 	parsers       map[Language]*parser.Parser
+	parsersMu     sync.Mutex
 	watcher       *fs.Watcher
+	filter        *fs.FileFilter
 
 	index         *index.Index
 	indexMu       sync.RWMutex
 	nPendingFiles int
 	lastIndexedAt time.Time
+	manifest      *manifest
+}
+
+// IndexStatus describes how up to date the index is
+type IndexStatus struct {
+	PendingFiles  int
+	LastIndexedAt time.Time
+	// Commit & branch the index was last updated against
+	Commit string
+	Branch string
+	// Commit currently checked out, differs from Commit until a branch switch is indexed
+	HeadCommit string
+	// Indexed files whose uncommitted changes are reflected in the index
+	UncommittedFiles []string
 }
 
-func New(ctx context.Context, workspaceRoot string) (*Analyzer, error) {
-	index, err := index.New(ctx, workspaceRoot)
+func New(ctx context.Context, workspaceRoot string, opts Options) (*Analyzer, error) {
+	index, err := index.New(ctx, workspaceRoot, opts.Index)
 	if err != nil {
 		return nil, err
 	}
 
 	analyzer := &Analyzer{
 		workspaceRoot: workspaceRoot,
+		opts:          opts,
 		parsers:       map[Language]*parser.Parser{},
+		filter:        fs.NewFileFilter(workspaceRoot, languages.supportedExts()),
 		index:         index,
 	}
 
+	if opts.Static {
+		return analyzer, nil
+	}
+
+	head, err := git.GetHead(workspaceRoot)
+	if err == nil {
+		analyzer.manifest, _ = loadManifest(manifestsDir, head.Commit)
+	}
+
 	go analyzer.IndexWorkspace(ctx)
 
 	w, err := fs.NewWatcher(
@@ -68,51 +112,154 @@ func (a *Analyzer) IndexWorkspace(ctx context.Context) {
 
 	// Clean up any files that were deleted while watcher wasn't running
 	a.index.CleanupDeletedFiles(ctx)
+
+	a.indexMu.Lock()
+	a.updateManifest(ctx)
+	a.indexMu.Unlock()
+
+	a.pruneEmbeddings(ctx)
 }
 
 func (a *Analyzer) handleFileChange(ctx context.Context, filePaths []string) {
 	a.processFiles(ctx, filePaths)
 }
 
-func (a *Analyzer) processFiles(ctx context.Context, filePaths []string) {
+// ReindexFiles synchronously reindexes specific files, e.g., ones an agent just edited,
+// without waiting for the watcher or touching other pending files. Paths outside the
+// workspace & ignored files, e.g., gitignored ones, are rejected.
+func (a *Analyzer) ReindexFiles(ctx context.Context, filePaths []string) map[string]error {
+	rejected := map[string]error{}
+	relPaths := make([]string, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		relPath, err := a.workspacePath(filePath)
+		if err != nil {
+			rejected[filePath] = err
+			continue
+		}
+
+		relPaths = append(relPaths, relPath)
+	}
+
+	if a.watcher != nil {
+		a.watcher.Forget(relPaths...)
+	}
+
+	errs := a.processFiles(ctx, relPaths)
+	if errs == nil {
+		errs = map[string]error{}
+	}
+	for filePath, err := range rejected {
+		errs[filePath] = err
+	}
+
+	return errs
+}
+
+// workspacePath returns a path relative to the workspace root, rejecting paths outside it & ignored files
+func (a *Analyzer) workspacePath(filePath string) (string, error) {
+	relPath := filepath.Clean(filePath)
+	if filepath.IsAbs(relPath) {
+		rel, err := filepath.Rel(a.workspaceRoot, relPath)
+		if err == nil {
+			relPath = rel
+		}
+	}
+
+	if filepath.IsAbs(relPath) || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the workspace", filePath)
+	}
+
+	if a.filter.ShouldIgnore(relPath) {
+		return "", fmt.Errorf("%s is ignored", filePath)
+	}
+
+	return relPath, nil
+}
+
+func (a *Analyzer) processFiles(ctx context.Context, filePaths []string) map[string]error {
 	if len(filePaths) == 0 {
-		return
+		return nil
 	}
 
 	a.indexMu.Lock()
 	defer a.indexMu.Unlock()
 
+	errs := make(map[string]error, len(filePaths))
 	a.nPendingFiles = len(filePaths)
 	for _, filePath := range filePaths {
-		a.chunk(ctx, filePath)
+		errs[filePath] = a.process(ctx, filePath)
 
 		a.nPendingFiles = max(a.nPendingFiles-1, 0)
 	}
 
 	a.lastIndexedAt = time.Now()
+	a.switchManifest(ctx)
+
+	return errs
+}
+
+// process (re)indexes a file, or drops it from the index if it no longer exists.
+// Paths of removed or renamed directories drop everything under them.
+func (a *Analyzer) process(ctx context.Context, filePath string) error {
+	_, err := os.Stat(filepath.Join(a.workspaceRoot, filePath))
+	if os.IsNotExist(err) {
+		err = a.index.Remove(ctx, filePath)
+		if err != nil {
+			return err
+		}
+
+		return a.index.RemoveDir(ctx, filePath)
+	}
+
+	return a.chunk(ctx, filePath)
 }
 
 func (a *Analyzer) getParser(filePath string) (*parser.Parser, error) {
-	lang := languages.detect(filepath.Ext(filePath))
-	parser, exists := a.parsers[lang]
+	return a.getLanguageParser(languages.detect(filePath))
+}
+
+func (a *Analyzer) getLanguageParser(lang Language) (*parser.Parser, error) {
+	p, exists := a.parsers[lang]
 	if exists {
-		return parser, nil
+		return p, nil
+	}
+
+	p, err := languages.createParser(a.workspaceRoot, lang)
+	if err != nil {
+		return nil, err
 	}
+	p.SetMaxChunkTokens(a.opts.MaxChunkTokens)
 
-	return languages.createParser(a.workspaceRoot, lang)
+	a.parsers[lang] = p
+	return p, nil
 }
 
-func (a *Analyzer) chunk(ctx context.Context, filePath string) error {
+// parse chunks a file, or source as the file's contents (e.g., at some past revision) if it's not nil.
+// Tree-sitter parsers aren't safe for concurrent use so they're shared behind a lock.
+func (a *Analyzer) parse(filePath string, source []byte) (*parser.File, error) {
+	a.parsersMu.Lock()
+	defer a.parsersMu.Unlock()
+
 	parser, err := a.getParser(filePath)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if source != nil {
+		return parser.ChunkSource(filePath, source)
 	}
 
-	file, err := parser.Chunk(filePath)
+	return parser.Chunk(filePath)
+}
+
+func (a *Analyzer) chunk(ctx context.Context, filePath string) error {
+	file, err := a.parse(filePath, nil)
 	if err != nil {
 		return err
 	}
 
+	a.describe(ctx, file)
+
 	err = a.index.Index(ctx, file)
 	if err != nil {
 		return err
@@ -121,68 +268,206 @@ func (a *Analyzer) chunk(ctx context.Context, filePath string) error {
 	return nil
 }
 
-func (a *Analyzer) SemanticSearch(ctx context.Context, query string, fileTypes []string) ([]string, error) {
-	a.flushPendingChanges()
-	return a.index.Search(ctx, query, fileTypes)
+// SearchOptions narrows down semantic search results
+type SearchOptions struct {
+	FileTypes    []string
+	ChangedSince string // only include chunks changed since this git ref
+	ChangedOnly  bool   // only include chunks touched by uncommitted changes
+	Expand       bool   // also search for related identifiers from the workspace's symbols
 }
 
-func (a *Analyzer) FindSimilarChunks(ctx context.Context, chunkID string) ([]string, error) {
-	a.flushPendingChanges()
-	return a.index.FindSimilarChunks(ctx, chunkID)
+func (a *Analyzer) SemanticSearch(ctx context.Context, queries []string, opts SearchOptions) ([]string, error) {
+	results, err := a.SearchAll(ctx, queries, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.FormatResults(results), nil
 }
 
-func (a *Analyzer) flushPendingChanges() {
-	if a.watcher != nil {
-		a.watcher.FlushPending()
-	}
+// Search returns the chunks that best match the query, most relevant first
+func (a *Analyzer) Search(ctx context.Context, query string, opts SearchOptions) ([]index.SearchResult, error) {
+	return a.SearchAll(ctx, []string{query}, opts)
 }
 
-func (a *Analyzer) GetChunkCode(ctx context.Context, ids []string) string {
-	result := ""
-	for _, id := range ids {
-		result += a.getSingleChunkCode(ctx, id)
+// SearchAll runs several queries at once, fusing their results into a single ranking
+func (a *Analyzer) SearchAll(ctx context.Context, queries []string, opts SearchOptions) ([]index.SearchResult, error) {
+	var filter index.ChunkFilter
+	if opts.ChangedSince != "" || opts.ChangedOnly {
+		var err error
+		filter, err = a.changedChunksFilter(opts.ChangedSince)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	queries = dedupeQueries(queries)
+
+	var expansions []string
+	if opts.Expand {
+		var err error
+		expansions, err = a.expandQueries(ctx, queries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	all := append(slices.Clip(queries), expansions...)
+	rankings := make([][]index.SearchResult, len(all))
+	errs := make([]error, len(all))
+
+	var wg sync.WaitGroup
+	for i, query := range all {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rankings[i], errs[i] = a.index.Search(ctx, query, opts.FileTypes, filter)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Identifier variants only fill in what the queries missed so that they can't push down good matches
+	results := fuseRankings(rankings[:len(queries)])
+	if len(expansions) > 0 {
+		results = appendMissing(results, fuseRankings(rankings[len(queries):]))
 	}
 
-	return result
+	if a.opts.Reranker == nil {
+		return results, nil
+	}
+
+	// Reranking is best effort, fall back to the fused order if it fails
+	reranked, err := a.opts.Reranker.Rerank(ctx, strings.Join(queries, "\n"), results)
+	if err != nil {
+		return results, nil
+	}
+
+	return reranked, nil
+}
+
+func (a *Analyzer) FindSymbol(ctx context.Context, query index.SymbolQuery) ([]index.SymbolMatch, error) {
+	return a.index.FindSymbol(ctx, query)
 }
 
-func (a *Analyzer) getSingleChunkCode(ctx context.Context, id string) string {
-	parts := strings.SplitN(id, "::", 2)
-	if len(parts) != 2 {
-		return fmt.Sprintf("== %s ==\n\n<invalid chunk id>\n\n", id)
+// ListMethods finds a type & its methods across the files of its package. The type can be
+// given by its chunk ID or package-qualified, e.g., pkg::Type.
+func (a *Analyzer) ListMethods(ctx context.Context, typeID string) (*parser.Chunk, []*parser.Chunk, error) {
+	typeChunk, err := a.index.GetChunk(ctx, typeID)
+	if err != nil {
+		resolution := a.resolve(ctx, typeID, newPackageLookup())
+		if resolution.ID == "" {
+			if len(resolution.Suggestions) > 0 {
+				return nil, nil, fmt.Errorf("%w, did you mean: %s", err, strings.Join(resolution.Suggestions, ", "))
+			}
+
+			return nil, nil, err
+		}
+
+		typeChunk, err = a.index.GetChunk(ctx, resolution.ID)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	err := a.chunk(ctx, parts[0])
+	methods, err := a.index.Methods(ctx, typeChunk)
 	if err != nil {
-		return fmt.Sprintf("== %s ==\n\n<processing error: %v>\n\n", id, err)
+		return nil, nil, err
 	}
 
-	chunk, err := a.index.GetChunk(ctx, id)
+	return typeChunk, methods, nil
+}
+
+func (a *Analyzer) FindSimilarChunks(ctx context.Context, chunkID string) ([]string, error) {
+	results, err := a.index.FindSimilarChunks(ctx, chunkID)
 	if err != nil {
-		return fmt.Sprintf("== %s ==\n\n<error getting source: %v>\n\n", id, err)
+		return nil, err
 	}
 
-	var lineInfo string
-	if chunk.StartLine == chunk.EndLine {
-		lineInfo = fmt.Sprintf(" [line %d]", chunk.StartLine)
-	} else {
-		lineInfo = fmt.Sprintf(" [lines %d-%d]", chunk.StartLine, chunk.EndLine)
+	return index.FormatResults(results), nil
+}
+
+const maxSimilarCode = 10
+
+// FindSimilarCode finds chunks similar to a code snippet, e.g., to discover existing helpers
+// before writing new ones. The snippet's language is optional but improves matches,
+// snippets in unsupported (or misspelled) languages are embedded as-is.
+func (a *Analyzer) FindSimilarCode(ctx context.Context, code, language string, fileTypes []string) ([]string, error) {
+	text := code
+	if language != "" {
+		a.parsersMu.Lock()
+		p, err := a.getLanguageParser(Language(strings.ToLower(language)))
+		if err == nil {
+			text = p.SnippetEmbeddingText(code)
+		}
+		a.parsersMu.Unlock()
+	}
+
+	results, err := a.index.Search(ctx, text, fileTypes, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return fmt.Sprintf("== %s%s ==\n\n%s\n\n", id, lineInfo, chunk.Source)
+	return index.FormatResults(results[:min(len(results), maxSimilarCode)]), nil
+}
+
+// FindDuplicates clusters duplicated & near-duplicated chunks, e.g., for refactoring planning
+func (a *Analyzer) FindDuplicates(ctx context.Context, opts index.DuplicateOptions) ([]index.DuplicateCluster, error) {
+	return a.index.FindDuplicates(ctx, opts)
+}
+
+// Topics clusters the workspace's chunks into topics, e.g., its major areas
+func (a *Analyzer) Topics(ctx context.Context, opts index.TopicOptions) ([]index.Topic, error) {
+	return a.index.Topics(ctx, opts)
 }
 
-func (a *Analyzer) GetIndexStatus() (int, time.Time) {
+func (a *Analyzer) flushPendingChanges() {
+	if a.watcher != nil {
+		a.watcher.FlushPending()
+	}
+}
+
+func (a *Analyzer) GetIndexStatus() IndexStatus {
 	a.indexMu.RLock()
-	pendingFiles := a.nPendingFiles
-	lastIndexedAt := a.lastIndexedAt
+	status := IndexStatus{
+		PendingFiles:  a.nPendingFiles,
+		LastIndexedAt: a.lastIndexedAt,
+	}
+	if a.manifest != nil {
+		status.Commit = a.manifest.Commit
+		status.Branch = a.manifest.Branch
+	}
 	a.indexMu.RUnlock()
 
 	if a.watcher != nil {
-		pendingFiles += a.watcher.PendingCount()
+		status.PendingFiles += a.watcher.PendingCount()
+	}
+
+	head, err := git.GetHead(a.workspaceRoot)
+	if err != nil {
+		return status
 	}
+	status.HeadCommit = head.Commit
 
-	return pendingFiles, lastIndexedAt
+	uncommitted, err := git.UncommittedFiles(a.workspaceRoot)
+	if err != nil {
+		return status
+	}
+
+	indexed := a.index.Files()
+	for _, filePath := range uncommitted {
+		_, exists := indexed[filePath]
+		if exists && !a.index.IsStale(context.Background(), filePath) {
+			status.UncommittedFiles = append(status.UncommittedFiles, filePath)
+		}
+	}
+
+	return status
 }
 
 func (a *Analyzer) Close() {
@@ -190,6 +475,13 @@ func (a *Analyzer) Close() {
 		a.watcher.Close()
 	}
 
+	a.indexMu.Lock()
+	a.updateManifest(context.Background())
+	a.indexMu.Unlock()
+
+	a.parsersMu.Lock()
+	defer a.parsersMu.Unlock()
+
 	for _, parser := range a.parsers {
 		parser.Close()
 	}
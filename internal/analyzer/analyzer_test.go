@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindexFiles(t *testing.T) {
+	a := newTestAnalyzer(t, testLinkFiles)
+
+	outside := filepath.Join(filepath.Dir(a.workspaceRoot), "x.go")
+	errs := a.ReindexFiles(context.Background(), []string{
+		"cart/cart.go",
+		filepath.Join(a.workspaceRoot, "shop/pricing.py"),
+		"../../x.go",
+		outside,
+		"notes.txt",
+	})
+
+	assert.NoError(t, errs["cart/cart.go"])
+	assert.NoError(t, errs["shop/pricing.py"])
+	assert.ErrorContains(t, errs["../../x.go"], "outside the workspace")
+	assert.ErrorContains(t, errs[outside], "outside the workspace")
+	assert.ErrorContains(t, errs["notes.txt"], "ignored")
+	assert.Len(t, errs, 5)
+}
+
+func TestFindSymbolKinds(t *testing.T) {
+	a := newTestAnalyzer(t, map[string]string{
+		"src/Counter.tsx": `export function Counter({ start }: { start: number }) {
+  return <span>{start}</span>;
+}
+
+export function useCounter(start: number) {
+  return start;
+}
+
+export class Page extends React.Component {
+  render() {
+    return <Counter start={1} />;
+  }
+}
+
+export function formatCount(count: number) {
+  return String(count);
+}
+`,
+	})
+
+	tests := []struct {
+		name     string
+		kind     string
+		expected []string
+	}{
+		{name: "Counter", kind: "function", expected: []string{"src/Counter.tsx::Counter"}},
+		{name: "Counter", kind: "component", expected: []string{"src/Counter.tsx::Counter"}},
+		{name: "Page", kind: "class", expected: []string{"src/Counter.tsx::Page"}},
+		{name: "Page", kind: "component", expected: []string{"src/Counter.tsx::Page"}},
+		{name: "useCounter", kind: "hook", expected: []string{"src/Counter.tsx::useCounter"}},
+		{name: "useCounter", kind: "component"},
+		{name: "formatCount", kind: "component"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.kind, func(t *testing.T) {
+			matches, err := a.FindSymbol(context.Background(), index.SymbolQuery{Name: tt.name, Kind: tt.kind, Mode: index.MatchExact})
+			require.NoError(t, err)
+
+			var ids []string
+			for _, match := range matches {
+				ids = append(ids, match.Chunk.ID())
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestFindSimilarCodeLanguages(t *testing.T) {
+	a := newTestAnalyzer(t, map[string]string{
+		"cart/cart.go": "package cart\n\nfunc ApplyDiscount(total, percent int) int {\n\treturn total - total*percent/100\n}\n",
+	})
+	ctx := context.Background()
+	code := "func discount(total, percent int) int {\n\treturn total - total*percent/100\n}"
+
+	asIs, err := a.FindSimilarCode(ctx, code, "", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, asIs)
+
+	// Unsupported languages fall back to matching the snippet as-is
+	for _, language := range []string{"cobol", "golang"} {
+		results, err := a.FindSimilarCode(ctx, code, language, nil)
+		require.NoError(t, err, language)
+		assert.Equal(t, asIs, results, language)
+	}
+
+	results, err := a.FindSimilarCode(ctx, code, "Go", nil)
+	require.NoError(t, err)
+	assert.Contains(t, results[0], "cart/cart.go::ApplyDiscount")
+}
+
+func TestCommonJSFiles(t *testing.T) {
+	a := newTestAnalyzer(t, map[string]string{
+		"lib/users.cjs":  "module.exports = {\n    parseUser: (raw) => JSON.parse(raw),\n    service: \"users\",\n};\n",
+		"lib/orders.mjs": "export function parseOrder(raw) {\n    return JSON.parse(raw);\n}\n",
+	})
+
+	code := a.GetChunkCode(context.Background(), []string{"lib/users.cjs::exports::parseUser", "lib/orders.mjs::parseOrder"}, ChunkCodeOptions{})
+	assert.Contains(t, code, "== lib/users.cjs::exports::parseUser [line 2] ==")
+	assert.Contains(t, code, "== lib/orders.mjs::parseOrder [lines 1-3] ==")
+	assert.NotContains(t, code, "<error")
+}
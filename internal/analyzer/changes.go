@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"context"
+	"sort"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/git"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// ChunkStatus describes how a chunk changed in a diff
+type ChunkStatus string
+
+const (
+	ChunkAdded    ChunkStatus = "added"
+	ChunkModified ChunkStatus = "modified"
+	ChunkDeleted  ChunkStatus = "deleted"
+)
+
+// ChangedChunk is a chunk touched by a diff
+type ChangedChunk struct {
+	Status ChunkStatus
+	Chunk  *parser.Chunk // the new version, or the old one for deleted chunks
+}
+
+// diff returns the changes since rev, or the uncommitted changes if rev is empty
+func (a *Analyzer) diff(rev string) ([]git.FileDiff, error) {
+	if rev == "" {
+		return git.UncommittedDiff(a.workspaceRoot)
+	}
+
+	return git.Diff(a.workspaceRoot, rev)
+}
+
+// changedChunksFilter restricts search results to chunks whose lines intersect the diff's hunks
+func (a *Analyzer) changedChunksFilter(rev string) (index.ChunkFilter, error) {
+	diffs, err := a.diff(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]git.FileDiff, len(diffs))
+	for _, diff := range diffs {
+		if diff.Status != git.FileDeleted {
+			changed[diff.Path] = diff
+		}
+	}
+
+	return func(chunk *parser.Chunk) bool {
+		diff, exists := changed[chunk.File]
+		if !exists {
+			return false
+		}
+
+		return diff.Status == git.FileAdded || touchesNew(diff.Hunks, chunk)
+	}, nil
+}
+
+// ChangedChunks maps the changes since rev (or the uncommitted changes if rev is empty)
+// to the chunks they added, modified, or deleted
+func (a *Analyzer) ChangedChunks(ctx context.Context, rev string) ([]ChangedChunk, error) {
+	diffs, err := a.diff(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	oldRev := rev
+	if oldRev == "" {
+		oldRev = "HEAD"
+	}
+
+	var changes []ChangedChunk
+	for _, diff := range diffs {
+		if languages.detect(diff.Path) == UnknownLang {
+			continue
+		}
+
+		newChunks := map[string]*parser.Chunk{}
+		if diff.Status != git.FileDeleted {
+			file, err := a.parse(diff.Path, nil)
+			if err != nil {
+				continue
+			}
+
+			for _, chunk := range file.Chunks {
+				newChunks[chunk.Path] = chunk
+			}
+		}
+
+		oldChunks := map[string]*parser.Chunk{}
+		if diff.Status != git.FileAdded {
+			source, err := git.Show(a.workspaceRoot, oldRev, diff.OldPath)
+			if err == nil && len(source) > 0 {
+				file, err := a.parse(diff.OldPath, source)
+				if err == nil {
+					for _, chunk := range file.Chunks {
+						oldChunks[chunk.Path] = chunk
+					}
+				}
+			}
+		}
+
+		for path, chunk := range newChunks {
+			_, existed := oldChunks[path]
+			switch {
+			case !existed:
+				changes = append(changes, ChangedChunk{Status: ChunkAdded, Chunk: chunk})
+			case touchesNew(diff.Hunks, chunk):
+				changes = append(changes, ChangedChunk{Status: ChunkModified, Chunk: chunk})
+			}
+		}
+
+		for path, chunk := range oldChunks {
+			_, exists := newChunks[path]
+			if !exists {
+				changes = append(changes, ChangedChunk{Status: ChunkDeleted, Chunk: chunk})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Chunk.File != changes[j].Chunk.File {
+			return changes[i].Chunk.File < changes[j].Chunk.File
+		}
+
+		return changes[i].Chunk.StartLine < changes[j].Chunk.StartLine
+	})
+
+	return changes, nil
+}
+
+func touchesNew(hunks []git.Hunk, chunk *parser.Chunk) bool {
+	for _, hunk := range hunks {
+		if hunk.TouchesNew(int(chunk.StartLine), int(chunk.EndLine)) {
+			return true
+		}
+	}
+
+	return false
+}
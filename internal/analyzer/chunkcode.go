@@ -0,0 +1,399 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// Chunk code modes
+const (
+	ModeFull      = "full"
+	ModeSignature = "signature" // declarations with function & method bodies elided
+)
+
+// ChunkCodeOptions expands what get_chunk_code returns beyond the chunk's source
+type ChunkCodeOptions struct {
+	Mode           string // ModeFull or ModeSignature
+	ContextLines   int    // number of surrounding lines to include before & after the chunk
+	IncludeParent  bool   // include the enclosing chunk's signature, e.g., the class of a method
+	IncludeImports bool   // include the file's imports (once per file)
+	LineNumbers    bool   // prefix each line with its line number
+	MaxTokens      int    // estimated token budget for the whole response, unlimited if <= 0
+}
+
+// minChunkTokens is the smallest budget worth spending on a (truncated) chunk
+const minChunkTokens = 64
+
+// chunkRequest is a requested chunk ID along with the chunk it refers to
+type chunkRequest struct {
+	id       string // as requested
+	resolved string // e.g., the file-qualified ID of a package-qualified one
+	filePath string // file defining the chunk, empty if the ID is invalid
+}
+
+func (a *Analyzer) GetChunkCode(ctx context.Context, ids []string, opts ChunkCodeOptions) string {
+	// Group IDs by file so that each file is parsed at most once
+	requests := make([]chunkRequest, len(ids))
+	parsed := map[string]*parser.File{}
+	packages := newPackageLookup()
+	refreshErrs := map[string]error{}
+	for i, id := range ids {
+		requests[i] = chunkRequest{id: id, resolved: id}
+		filePath, _, ok := strings.Cut(id, "::")
+		if !ok {
+			continue
+		}
+		requests[i].filePath = filePath
+
+		// Package-qualified IDs, e.g., pkg::Type::method, refer to the file defining the chunk
+		if !a.isFile(filePath) {
+			resolution, qualified := a.resolveQualified(ctx, id, packages)
+			if qualified && resolution.ID != "" {
+				requests[i].resolved = resolution.ID
+				requests[i].filePath, _, _ = strings.Cut(resolution.ID, "::")
+			}
+
+			// Unresolved package-qualified IDs don't name a file
+			if qualified && resolution.ID == "" {
+				continue
+			}
+		}
+		filePath = requests[i].filePath
+
+		_, refreshed := refreshErrs[filePath]
+		if !refreshed {
+			refreshErrs[filePath] = a.refresh(ctx, filePath, parsed)
+		}
+	}
+
+	// IDs are served in the requested order until the token budget runs out
+	result := ""
+	omitted := []string{}
+	seenFiles := map[string]bool{}
+	for _, request := range requests {
+		remaining := 0
+		if opts.MaxTokens > 0 {
+			remaining = opts.MaxTokens - parser.EstimateTokens(result)
+			if remaining < minChunkTokens {
+				omitted = append(omitted, request.id)
+				continue
+			}
+		}
+
+		filePath := request.filePath
+		refreshErr, refreshed := refreshErrs[filePath]
+		if opts.IncludeImports && refreshed && refreshErr == nil && !seenFiles[filePath] {
+			imports := a.getImports(filePath, parsed)
+			tokens := parser.EstimateTokens(imports)
+			if opts.MaxTokens <= 0 || remaining-tokens >= minChunkTokens {
+				result += imports
+				remaining -= tokens
+			}
+		}
+		seenFiles[filePath] = true
+
+		result += a.getSingleChunkCode(ctx, request, refreshErr, parsed, packages, opts, remaining)
+	}
+
+	if len(omitted) > 0 {
+		result += fmt.Sprintf(
+			"<token budget of ~%d reached, omitted: %s>\n",
+			opts.MaxTokens, strings.Join(omitted, ", "),
+		)
+	}
+
+	return result
+}
+
+// refresh makes sure that the chunks served for a file reflect its current content.
+// Stale files are parsed & staged in memory, then queued for embedding since
+// embedding on the read path would be slow.
+func (a *Analyzer) refresh(ctx context.Context, filePath string, parsed map[string]*parser.File) error {
+	if !a.index.IsStale(ctx, filePath) || a.index.IsStaged(filePath) {
+		return nil
+	}
+
+	// Missing files, e.g., moved or misspelled ones, are left to the resolver
+	_, err := os.Stat(filepath.Join(a.workspaceRoot, filePath))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	file, err := a.parseCached(filePath, parsed)
+	if err != nil {
+		return err
+	}
+
+	a.index.Stage(file)
+	if a.watcher != nil {
+		a.watcher.Enqueue(filePath)
+	}
+
+	return nil
+}
+
+// isFile reports whether a path is a file in the workspace, rather than, e.g., a package
+func (a *Analyzer) isFile(filePath string) bool {
+	info, err := os.Stat(filepath.Join(a.workspaceRoot, filePath))
+	return err == nil && !info.IsDir()
+}
+
+// parseCached parses a file unless it was already parsed while serving the same request
+func (a *Analyzer) parseCached(filePath string, parsed map[string]*parser.File) (*parser.File, error) {
+	file, cached := parsed[filePath]
+	if cached {
+		return file, nil
+	}
+
+	file, err := a.parse(filePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed[filePath] = file
+	return file, nil
+}
+
+func (a *Analyzer) getImports(filePath string, parsed map[string]*parser.File) string {
+	file, err := a.parseCached(filePath, parsed)
+	if err != nil {
+		return ""
+	}
+
+	imports := a.imports(file)
+	if imports == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("== %s (imports) ==\n\n%s\n\n", filePath, imports)
+}
+
+// fileImports returns the import statements of a file, if any
+func (a *Analyzer) fileImports(filePath string) string {
+	file, err := a.parse(filePath, nil)
+	if err != nil {
+		return ""
+	}
+
+	return a.imports(file)
+}
+
+// imports returns the import statements of a parsed file, if any
+func (a *Analyzer) imports(file *parser.File) string {
+	a.parsersMu.Lock()
+	defer a.parsersMu.Unlock()
+
+	parser, err := a.getParser(file.Path)
+	if err != nil {
+		return ""
+	}
+
+	return parser.Imports(file)
+}
+
+func (a *Analyzer) getSingleChunkCode(
+	ctx context.Context,
+	request chunkRequest,
+	refreshErr error,
+	parsed map[string]*parser.File,
+	packages *packageLookup,
+	opts ChunkCodeOptions,
+	maxTokens int,
+) string {
+	id := request.id
+	if request.filePath == "" {
+		return fmt.Sprintf("== %s ==\n\n<invalid chunk id>\n\n", id)
+	}
+
+	if refreshErr != nil {
+		return fmt.Sprintf("== %s ==\n\n<processing error: %v>\n\n", id, refreshErr)
+	}
+
+	resolvedFrom := ""
+	if request.resolved != id {
+		resolvedFrom = fmt.Sprintf(" (resolved from %s)", request.id)
+		id = request.resolved
+	}
+
+	chunk, err := a.index.GetChunk(ctx, id)
+	if err != nil {
+		resolution := a.resolve(ctx, id, packages)
+		if resolution.ID == "" {
+			didYouMean := ""
+			if len(resolution.Suggestions) > 0 {
+				didYouMean = "\nDid you mean: " + strings.Join(resolution.Suggestions, ", ")
+			}
+
+			return fmt.Sprintf("== %s ==\n\n<error getting source: %v>%s\n\n", id, err, didYouMean)
+		}
+
+		chunk, err = a.index.GetChunk(ctx, resolution.ID)
+		if err != nil {
+			return fmt.Sprintf("== %s ==\n\n<error getting source: %v>\n\n", id, err)
+		}
+
+		resolvedFrom = fmt.Sprintf(" (resolved from %s)", request.id)
+		id = resolution.ID
+	}
+
+	parent := ""
+	if opts.IncludeParent {
+		parent = a.getParentSignature(ctx, chunk)
+	}
+
+	var source string
+	var firstLine uint
+	if opts.Mode == ModeSignature {
+		// Elided bodies shift lines, so they no longer map to the file
+		source = a.getSkeleton(chunk, parsed)
+	} else {
+		source, firstLine = a.renderSource(chunk, opts)
+	}
+
+	header := fmt.Sprintf("== %s [%s]%s ==\n\n%s", id, chunk.Lines(), resolvedFrom, parent)
+	if maxTokens > 0 {
+		source = truncate(source, firstLine, maxTokens-parser.EstimateTokens(header))
+	}
+
+	return fmt.Sprintf("%s%s\n\n", header, source)
+}
+
+// truncate cuts source down to roughly maxTokens, marking the lines it omits.
+// firstLine is the file line of the source's first line, or 0 if they don't map.
+func truncate(source string, firstLine uint, maxTokens int) string {
+	if parser.EstimateTokens(source) <= maxTokens {
+		return source
+	}
+
+	lines := strings.Split(source, "\n")
+	markerTokens := parser.EstimateTokens(truncationMarker(firstLine, len(lines), len(lines), source))
+
+	kept, tokens := 0, 0
+	for kept < len(lines) {
+		lineTokens := parser.EstimateTokens(lines[kept] + "\n")
+		if tokens+lineTokens+markerTokens > maxTokens {
+			break
+		}
+
+		tokens += lineTokens
+		kept++
+	}
+
+	rest := strings.Join(lines[kept:], "\n")
+	marker := truncationMarker(firstLine, kept, len(lines), rest)
+	if kept == 0 {
+		return marker
+	}
+
+	return strings.Join(lines[:kept], "\n") + "\n" + marker
+}
+
+func truncationMarker(firstLine uint, kept, total int, rest string) string {
+	omitted := fmt.Sprintf("%d lines", total-kept)
+	if firstLine > 0 {
+		omitted = fmt.Sprintf("lines %d-%d", int(firstLine)+kept, int(firstLine)+total-1)
+	}
+
+	return fmt.Sprintf(
+		"<truncated: %s omitted (~%d tokens), raise max_tokens to see more>",
+		omitted, parser.EstimateTokens(rest),
+	)
+}
+
+// getSkeleton returns the chunk with its bodies elided, falling back to the full source
+func (a *Analyzer) getSkeleton(chunk *parser.Chunk, parsed map[string]*parser.File) string {
+	file, err := a.parseCached(chunk.File, parsed)
+	if err != nil {
+		return chunk.Source
+	}
+
+	a.parsersMu.Lock()
+	defer a.parsersMu.Unlock()
+
+	parser, err := a.getParser(chunk.File)
+	if err != nil {
+		return chunk.Source
+	}
+
+	skeleton, err := parser.Skeleton(file, chunk.Path)
+	if err != nil {
+		return chunk.Source
+	}
+
+	return skeleton
+}
+
+// getParentSignature returns the signature of the chunk enclosing this one,
+// e.g., the class declaration of a method
+func (a *Analyzer) getParentSignature(ctx context.Context, chunk *parser.Chunk) string {
+	// The parent of a part is the chunk it was split from
+	parentPath, isPart := parser.PartOf(chunk.Path)
+	if !isPart {
+		separator := strings.LastIndex(chunk.Path, "::")
+		if separator < 0 {
+			return ""
+		}
+
+		parentPath = chunk.Path[:separator]
+	}
+
+	parentID := chunk.File + "::" + parentPath
+	parent, err := a.index.GetChunk(ctx, parentID)
+	if err != nil || parent.Signature == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("parent %s [line %d]: %s\n\n", parentID, declarationLine(parent), parent.Signature)
+}
+
+// declarationLine returns the line of the chunk's signature, i.e., skipping folded comments
+func declarationLine(chunk *parser.Chunk) uint {
+	for i, line := range strings.Split(chunk.Source, "\n") {
+		if strings.TrimSpace(line) == chunk.Signature {
+			return chunk.StartLine + uint(i)
+		}
+	}
+
+	return chunk.StartLine
+}
+
+// renderSource returns the chunk's source, optionally with surrounding lines & line numbers,
+// along with the file line that it starts at
+func (a *Analyzer) renderSource(chunk *parser.Chunk, opts ChunkCodeOptions) (string, uint) {
+	if opts.ContextLines <= 0 && !opts.LineNumbers {
+		return chunk.Source, chunk.StartLine
+	}
+
+	startLine := chunk.StartLine
+	lines := strings.Split(chunk.Source, "\n")
+
+	// Read the full lines from the file so that the context & indentation are accurate
+	source, err := os.ReadFile(filepath.Join(a.workspaceRoot, chunk.File))
+	if err == nil {
+		fileLines := strings.Split(string(source), "\n")
+		if int(chunk.EndLine) <= len(fileLines) {
+			first := max(int(chunk.StartLine)-opts.ContextLines, 1)
+			last := min(int(chunk.EndLine)+opts.ContextLines, len(fileLines))
+
+			startLine = uint(first)
+			lines = fileLines[first-1 : last]
+		}
+	}
+
+	if !opts.LineNumbers {
+		return strings.Join(lines, "\n"), startLine
+	}
+
+	width := len(fmt.Sprint(int(startLine) + len(lines) - 1))
+	numbered := make([]string, len(lines))
+	for i, line := range lines {
+		numbered[i] = fmt.Sprintf("%*d | %s", width, int(startLine)+i, line)
+	}
+
+	return strings.Join(numbered, "\n"), startLine
+}
@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/fs"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// numberedLines returns n lines of source, each ~4 tokens long
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("total += item%02d", i+1)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func TestTruncate(t *testing.T) {
+	source := numberedLines(10)
+
+	tests := []struct {
+		name      string
+		firstLine uint
+		maxTokens int
+		expected  string
+	}{
+		{name: "within budget", firstLine: 1, maxTokens: 100, expected: source},
+		{
+			name:      "at budget",
+			firstLine: 20,
+			maxTokens: 30,
+			expected:  "total += item01\ntotal += item02\n<truncated: lines 22-29 omitted (~32 tokens), raise max_tokens to see more>",
+		},
+		{
+			name:      "lines that don't map to the file",
+			maxTokens: 30,
+			expected:  "total += item01\ntotal += item02\ntotal += item03\n<truncated: 7 lines omitted (~28 tokens), raise max_tokens to see more>",
+		},
+		{
+			name:      "budget below the marker",
+			firstLine: 20,
+			maxTokens: 5,
+			expected:  "<truncated: lines 20-29 omitted (~40 tokens), raise max_tokens to see more>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, truncate(source, tt.firstLine, tt.maxTokens))
+		})
+	}
+}
+
+func TestGetChunkCodeBudget(t *testing.T) {
+	body := "\t" + strings.ReplaceAll(numberedLines(20), "\n", "\n\t")
+	source := "package cart\n\nvar total int\n"
+	for _, name := range []string{"First", "Second", "Third"} {
+		source += fmt.Sprintf("\nfunc %s() {\n%s\n}\n", name, body)
+	}
+
+	a := newTestAnalyzer(t, map[string]string{"cart.go": source})
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		ids       []string
+		maxTokens int
+		served    []string
+		truncated bool
+		omitted   string
+	}{
+		{
+			name:      "in the requested order",
+			ids:       []string{"cart.go::Third", "cart.go::First", "cart.go::Second"},
+			maxTokens: 1000,
+			served:    []string{"cart.go::Third", "cart.go::First", "cart.go::Second"},
+		},
+		{
+			name:      "truncated at the budget",
+			ids:       []string{"cart.go::Second", "cart.go::First"},
+			maxTokens: 180,
+			served:    []string{"cart.go::Second", "cart.go::First"},
+			truncated: true,
+		},
+		{
+			name:      "omitted below the minimum chunk budget",
+			ids:       []string{"cart.go::Third", "cart.go::First", "cart.go::Second"},
+			maxTokens: 125,
+			served:    []string{"cart.go::Third"},
+			omitted:   "<token budget of ~125 reached, omitted: cart.go::First, cart.go::Second>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := a.GetChunkCode(ctx, tt.ids, ChunkCodeOptions{MaxTokens: tt.maxTokens})
+
+			last := -1
+			for _, id := range tt.served {
+				header := strings.Index(code, "== "+id+" ")
+				assert.Greater(t, header, last, id)
+				last = header
+			}
+
+			assert.Equal(t, tt.truncated, strings.Contains(code, "<truncated:"))
+			if tt.omitted != "" {
+				assert.Contains(t, code, tt.omitted)
+			} else {
+				assert.NotContains(t, code, "<token budget")
+			}
+
+			budget := parser.EstimateTokens(strings.TrimSuffix(code, tt.omitted+"\n"))
+			assert.LessOrEqual(t, budget, tt.maxTokens)
+		})
+	}
+}
+
+func TestGetChunkCodeMissingFile(t *testing.T) {
+	a := newTestAnalyzer(t, map[string]string{
+		"cart/cart.go": "package cart\n\nfunc Total() int {\n\treturn 0\n}\n",
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{name: "renamed file", id: "cart/old.go::Total"},
+		{name: "wrong case", id: "cart/Cart.go::Total"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := a.GetChunkCode(ctx, []string{tt.id}, ChunkCodeOptions{})
+			assert.Contains(t, code, "== cart/cart.go::Total [lines 3-5] (resolved from "+tt.id+") ==")
+			assert.Contains(t, code, "return 0")
+			assert.NotContains(t, code, "<processing error")
+		})
+	}
+}
+
+func TestGetChunkCodeQueuesStaleFiles(t *testing.T) {
+	a := newTestAnalyzer(t, map[string]string{
+		"cart.go": "package cart\n\nfunc Total() int {\n\treturn 0\n}\n",
+	})
+	ctx := context.Background()
+
+	// Edited behind the index's back, e.g., while the watcher was busy
+	fullPath := filepath.Join(a.workspaceRoot, "cart.go")
+	source := "package cart\n\nfunc Total() int {\n\treturn 0\n}\n\nfunc ApplyDiscount(total, percent int) int {\n\treturn total - total*percent/100\n}\n"
+	require.NoError(t, os.WriteFile(fullPath, []byte(source), 0o644))
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(fullPath, later, later))
+
+	w, err := fs.NewWatcher(ctx, a.workspaceRoot, languages.supportedExts(), a.handleFileChange)
+	require.NoError(t, err)
+	t.Cleanup(func() { w.Close() })
+	a.watcher = w
+
+	// Served from the staged parse while the file waits to be embedded
+	code := a.GetChunkCode(ctx, []string{"cart.go::ApplyDiscount"}, ChunkCodeOptions{})
+	assert.Contains(t, code, "return total - total*percent/100")
+	assert.True(t, a.index.IsStaged("cart.go"))
+	assert.Equal(t, 1, w.PendingCount())
+
+	a.flushPendingChanges()
+	assert.Equal(t, 0, w.PendingCount())
+	assert.False(t, a.index.IsStaged("cart.go"))
+	assert.False(t, a.index.IsStale(ctx, "cart.go"))
+
+	results, err := a.Search(ctx, "ApplyDiscount total percent", SearchOptions{})
+	require.NoError(t, err)
+	ids := []string{}
+	for _, result := range results {
+		ids = append(ids, result.Chunk.ID())
+	}
+	assert.Contains(t, ids, "cart.go::ApplyDiscount")
+}
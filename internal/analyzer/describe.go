@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// maxConcurrentDescriptions bounds the in-flight requests to the summarizer
+const maxConcurrentDescriptions = 4
+
+// describe adds generated descriptions to the file's named chunks,
+// reusing the descriptions of chunks whose source hasn't changed since they were indexed
+func (a *Analyzer) describe(ctx context.Context, file *parser.File) {
+	if a.opts.Summarizer == nil {
+		return
+	}
+
+	descriptions := make([]string, len(file.Chunks))
+	semaphore := make(chan struct{}, maxConcurrentDescriptions)
+
+	var wg sync.WaitGroup
+	for i, chunk := range file.Chunks {
+		if chunk.Name == "" {
+			continue
+		}
+
+		indexed, err := a.index.GetChunk(ctx, chunk.ID())
+		if err == nil && indexed.Source == chunk.Source && indexed.Description != "" {
+			descriptions[i] = indexed.Description
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// Descriptions are best effort, chunks are still indexed without them
+			description, err := a.opts.Summarizer.Describe(ctx, chunk)
+			if err == nil {
+				descriptions[i] = description
+			}
+		}()
+	}
+	wg.Wait()
+
+	a.parsersMu.Lock()
+	defer a.parsersMu.Unlock()
+
+	parser, err := a.getParser(file.Path)
+	if err != nil {
+		return
+	}
+
+	for i, chunk := range file.Chunks {
+		if descriptions[i] != "" {
+			parser.Describe(chunk, descriptions[i])
+		}
+	}
+}
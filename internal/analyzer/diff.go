@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContextLines = 2
+
+// diffLines returns a unified-style line diff between two versions of a chunk,
+// showing only changed lines & a little context around them
+func diffLines(before, after string) string {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] & b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type diffLine struct {
+		op   byte
+		text string
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+
+	// Only keep changed lines & their context
+	keep := make([]bool, len(lines))
+	for k, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+
+		for c := max(k-diffContextLines, 0); c <= min(k+diffContextLines, len(lines)-1); c++ {
+			keep[c] = true
+		}
+	}
+
+	var sb strings.Builder
+	skipped := false
+	for k, line := range lines {
+		if !keep[k] {
+			skipped = true
+			continue
+		}
+
+		if skipped && sb.Len() > 0 {
+			sb.WriteString("@@\n")
+		}
+		skipped = false
+
+		fmt.Fprintf(&sb, "%c %s\n", line.op, line.text)
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
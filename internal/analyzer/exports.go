@@ -0,0 +1,178 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+)
+
+// maxExportDepth bounds how many packages re-exporting each other are followed, e.g., pkg -> pkg.sub
+const maxExportDepth = 4
+
+// packageInit is the file whose imports a Python package re-exports
+const packageInit = "__init__.py"
+
+// packageLookup remembers the __init__.py files of packages while serving a request,
+// so that the index's files are listed at most once however many IDs get resolved
+type packageLookup struct {
+	files map[string]string // indexed files, listed on first use
+	inits map[string]string // qualifier -> __init__.py of its package, "" if there's none
+}
+
+func newPackageLookup() *packageLookup {
+	return &packageLookup{inits: map[string]string{}}
+}
+
+// resolve finds the chunk an agent most likely meant by a chunk ID that doesn't exist,
+// trying names re-exported by packages before the index's fuzzier resolution
+func (a *Analyzer) resolve(ctx context.Context, id string, packages *packageLookup) index.Resolution {
+	resolved, ok := a.resolveExport(ctx, id, 0, packages)
+	if ok {
+		return index.Resolution{ID: resolved}
+	}
+
+	return a.index.Resolve(ctx, id)
+}
+
+// resolveQualified resolves an ID qualified by a package rather than a file, e.g., pkg::User,
+// whether the package declares or re-exports the chunk. ok is false if the ID starts with a file.
+func (a *Analyzer) resolveQualified(ctx context.Context, id string, packages *packageLookup) (index.Resolution, bool) {
+	resolved, ok := a.resolveExport(ctx, id, 0, packages)
+	if ok {
+		return index.Resolution{ID: resolved}, true
+	}
+
+	return a.index.ResolvePackageID(ctx, id)
+}
+
+// resolveExport resolves an ID qualified by a package that re-exports the chunk, e.g., shop::Product
+// when shop/__init__.py has from .models import Product, following packages that re-export each other
+func (a *Analyzer) resolveExport(ctx context.Context, id string, depth int, packages *packageLookup) (string, bool) {
+	qualifier, chunkPath, found := strings.Cut(id, "::")
+	if !found || chunkPath == "" || depth > maxExportDepth {
+		return "", false
+	}
+
+	initFile, looked := packages.inits[qualifier]
+	if !looked {
+		initFile = a.findPackageInit(qualifier, packages)
+		packages.inits[qualifier] = initFile
+	}
+
+	if initFile == "" {
+		return "", false
+	}
+
+	file, err := a.parse(initFile, nil)
+	if err != nil {
+		return "", false
+	}
+
+	name, rest, _ := strings.Cut(chunkPath, "::")
+	for _, export := range file.Exports {
+		var candidate string
+		switch {
+		case export.Name == "*":
+			candidate = export.Target + "::" + chunkPath
+		case export.Name != name:
+			continue
+		case strings.Contains(export.Target, "::"):
+			candidate = export.Target
+			if rest != "" {
+				candidate += "::" + rest
+			}
+		case rest != "":
+			// Modules are exported as files, e.g., pkg::models::User
+			candidate = export.Target + "::" + rest
+		default:
+			continue
+		}
+
+		_, err := a.index.GetChunk(ctx, candidate)
+		if err == nil {
+			return candidate, true
+		}
+
+		// The target may itself be re-exported by another package
+		resolved, ok := a.resolveExport(ctx, candidate, depth+1, packages)
+		if ok {
+			return resolved, true
+		}
+	}
+
+	return "", false
+}
+
+// findPackageInit finds the __init__.py of the package named by qualifier, i.e., the file itself,
+// its directory, or its dotted import path, e.g., shop.models
+func (a *Analyzer) findPackageInit(qualifier string, packages *packageLookup) string {
+	if path.Base(qualifier) == packageInit {
+		return qualifier
+	}
+
+	if packages.files == nil {
+		packages.files = a.index.Files()
+	}
+	files := packages.files
+
+	// Other files don't re-export names
+	_, indexed := files[qualifier]
+	if indexed || strings.HasSuffix(qualifier, ".py") {
+		return ""
+	}
+
+	dirs := []string{qualifier}
+	if !strings.Contains(qualifier, "/") {
+		dirs = append(dirs, strings.ReplaceAll(qualifier, ".", "/"))
+	}
+
+	for _, dir := range dirs {
+		if a.isPackage(dir) {
+			return path.Join(dir, packageInit)
+		}
+	}
+
+	// Import paths are relative to their source root, e.g., src/. __init__.py files with only
+	// imports have no chunks so packages are found via the directories of their modules.
+	seen := map[string]bool{}
+	var matches []string
+	for filePath := range files {
+		fileDir := path.Dir(filePath)
+		for _, dir := range dirs {
+			if !strings.HasSuffix(fileDir, "/"+dir) || seen[fileDir] {
+				continue
+			}
+
+			seen[fileDir] = true
+			if a.isPackage(fileDir) {
+				matches = append(matches, path.Join(fileDir, packageInit))
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return ""
+	}
+
+	// Prefer the shallowest package, e.g., src/pkg over src/vendor/x/pkg
+	sort.Slice(matches, func(i, j int) bool {
+		if len(matches[i]) != len(matches[j]) {
+			return len(matches[i]) < len(matches[j])
+		}
+
+		return matches[i] < matches[j]
+	})
+
+	return matches[0]
+}
+
+// isPackage reports whether a workspace directory is a Python package, i.e., has an __init__.py
+func (a *Analyzer) isPackage(dir string) bool {
+	info, err := os.Stat(filepath.Join(a.workspaceRoot, dir, packageInit))
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,119 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var exportFiles = map[string]string{
+	"src/shop/__init__.py": `from .models import Product as Item
+from . import pricing
+from .catalog import *
+`,
+	"src/shop/models.py": `class Product:
+    def price(self):
+        return 1
+`,
+	"src/shop/pricing.py": `import math
+
+
+def apply_tax(cents):
+    return cents
+`,
+	"src/shop/catalog/__init__.py": `from .search import find
+`,
+	"src/shop/catalog/search.py": `def find(query):
+    return query
+`,
+}
+
+func TestResolveExport(t *testing.T) {
+	a := newTestAnalyzer(t, exportFiles)
+
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{
+			name:     "aliased name by import path",
+			id:       "shop::Item",
+			expected: "src/shop/models.py::Product",
+		},
+		{
+			name:     "member of an exported name",
+			id:       "shop::Item::price",
+			expected: "src/shop/models.py::Product::price",
+		},
+		{
+			name:     "exported module",
+			id:       "shop::pricing::apply_tax",
+			expected: "src/shop/pricing.py::apply_tax",
+		},
+		{
+			name:     "wildcard re-exported by a subpackage",
+			id:       "shop::find",
+			expected: "src/shop/catalog/search.py::find",
+		},
+		{
+			name:     "package directory",
+			id:       "src/shop::Item",
+			expected: "src/shop/models.py::Product",
+		},
+		{
+			name:     "package module",
+			id:       "shop.models::Product",
+			expected: "src/shop/models.py::Product",
+		},
+		{
+			name: "unexported name",
+			id:   "shop::Missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, _ := a.resolveQualified(context.Background(), tt.id, newPackageLookup())
+			assert.Equal(t, tt.expected, resolution.ID)
+		})
+	}
+}
+
+func TestGetChunkCodeViaExport(t *testing.T) {
+	a := newTestAnalyzer(t, exportFiles)
+
+	code := a.GetChunkCode(context.Background(), []string{"shop::Item"}, ChunkCodeOptions{})
+	assert.Contains(t, code, "== src/shop/models.py::Product [lines 1-3] (resolved from shop::Item) ==")
+	assert.Contains(t, code, "class Product:")
+}
+
+func TestGetChunkCodeImportsViaExport(t *testing.T) {
+	a := newTestAnalyzer(t, exportFiles)
+
+	code := a.GetChunkCode(context.Background(), []string{"shop::pricing::apply_tax"}, ChunkCodeOptions{IncludeImports: true})
+	assert.Contains(t, code, "== src/shop/pricing.py (imports) ==\n\nimport math")
+	assert.Contains(t, code, "(resolved from shop::pricing::apply_tax) ==")
+}
+
+func TestPackageLookup(t *testing.T) {
+	a := newTestAnalyzer(t, exportFiles)
+	ctx := context.Background()
+	packages := newPackageLookup()
+
+	for _, id := range []string{"shop::Item", "shop::pricing::apply_tax", "shop::find"} {
+		resolution, _ := a.resolveQualified(ctx, id, packages)
+		assert.NotEmpty(t, resolution.ID, id)
+	}
+
+	// Packages are looked up once per request
+	assert.Equal(t, map[string]string{
+		"shop":                         "src/shop/__init__.py",
+		"src/shop/catalog/__init__.py": "src/shop/catalog/__init__.py",
+	}, packages.inits)
+
+	// IDs starting with a file are served without qualified resolution
+	code := a.GetChunkCode(ctx, []string{"src/shop/models.py::Product"}, ChunkCodeOptions{})
+	assert.Contains(t, code, "== src/shop/models.py::Product [lines 1-3] ==")
+}
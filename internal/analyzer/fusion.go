@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+)
+
+const (
+	// rrfK dampens the advantage of top ranks in reciprocal rank fusion
+	rrfK = 60
+
+	maxFusedResults = 30
+	maxExpansions   = 3
+)
+
+// dedupeQueries drops empty & repeated queries, ignoring case
+func dedupeQueries(queries []string) []string {
+	seen := map[string]bool{}
+	deduped := make([]string, 0, len(queries))
+	for _, query := range queries {
+		query = strings.TrimSpace(query)
+		key := strings.ToLower(query)
+		if key == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		deduped = append(deduped, query)
+	}
+
+	return deduped
+}
+
+// expandQueries finds identifiers related to the queries in the workspace's symbols,
+// skipping ones that repeat a query
+func (a *Analyzer) expandQueries(ctx context.Context, queries []string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, query := range queries {
+		seen[strings.ToLower(query)] = true
+	}
+
+	var expansions []string
+	for _, query := range queries {
+		related, err := a.index.ExpandQuery(ctx, query, maxExpansions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, expansion := range related {
+			key := strings.ToLower(expansion)
+			if !seen[key] {
+				seen[key] = true
+				expansions = append(expansions, expansion)
+			}
+		}
+	}
+
+	return expansions, nil
+}
+
+// fuseRankings merges rankings using reciprocal rank fusion, deduplicating by chunk ID.
+// Chunks ranked well by several queries rise to the top.
+func fuseRankings(rankings [][]index.SearchResult) []index.SearchResult {
+	if len(rankings) == 1 {
+		return rankings[0]
+	}
+
+	scores := map[string]float64{}
+	fused := map[string]index.SearchResult{}
+	for _, ranking := range rankings {
+		for rank, result := range ranking {
+			id := result.Chunk.ID()
+			scores[id] += 1 / float64(rrfK+rank+1)
+
+			existing, ok := fused[id]
+			if !ok || result.Similarity > existing.Similarity {
+				fused[id] = result
+			}
+		}
+	}
+
+	results := make([]index.SearchResult, 0, len(fused))
+	for _, result := range fused {
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if scores[a.Chunk.ID()] != scores[b.Chunk.ID()] {
+			return scores[a.Chunk.ID()] > scores[b.Chunk.ID()]
+		}
+
+		if a.Similarity != b.Similarity {
+			return a.Similarity > b.Similarity
+		}
+
+		return a.Chunk.ID() < b.Chunk.ID()
+	})
+
+	return results[:min(len(results), maxFusedResults)]
+}
+
+// appendMissing appends the extra results that aren't already in results
+func appendMissing(results, extra []index.SearchResult) []index.SearchResult {
+	seen := make(map[string]bool, len(results))
+	for _, result := range results {
+		seen[result.Chunk.ID()] = true
+	}
+
+	for _, result := range extra {
+		if len(results) >= maxFusedResults {
+			break
+		}
+
+		if !seen[result.Chunk.ID()] {
+			seen[result.Chunk.ID()] = true
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
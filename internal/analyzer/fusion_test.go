@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func ranking(ids ...string) []index.SearchResult {
+	results := make([]index.SearchResult, len(ids))
+	for i, id := range ids {
+		results[i] = index.SearchResult{
+			Chunk:      &parser.Chunk{File: "file.go", Path: id},
+			Similarity: 0.9 - float32(i)/10,
+		}
+	}
+
+	return results
+}
+
+func paths(results []index.SearchResult) []string {
+	paths := make([]string, len(results))
+	for i, result := range results {
+		paths[i] = result.Chunk.Path
+	}
+
+	return paths
+}
+
+func TestFuseRankings(t *testing.T) {
+	tests := []struct {
+		name     string
+		rankings [][]index.SearchResult
+		expected []string
+	}{
+		{
+			name:     "SingleRanking",
+			rankings: [][]index.SearchResult{ranking("A", "B", "C")},
+			expected: []string{"A", "B", "C"},
+		},
+		{
+			name:     "AgreementWins",
+			rankings: [][]index.SearchResult{ranking("A", "B", "C"), ranking("D", "B", "E")},
+			expected: []string{"B", "A", "D", "C", "E"},
+		},
+		{
+			name:     "Dedupe",
+			rankings: [][]index.SearchResult{ranking("A", "B"), ranking("A", "B")},
+			expected: []string{"A", "B"},
+		},
+		{
+			name:     "Empty",
+			rankings: [][]index.SearchResult{ranking(), ranking("A")},
+			expected: []string{"A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, paths(fuseRankings(tt.rankings)))
+		})
+	}
+}
+
+func TestAppendMissing(t *testing.T) {
+	results := appendMissing(ranking("A", "B"), ranking("C", "A", "D"))
+	assert.Equal(t, []string{"A", "B", "C", "D"}, paths(results))
+}
+
+func TestDedupeQueries(t *testing.T) {
+	queries := dedupeQueries([]string{"rate limiting", " Rate Limiting ", "", "throttle"})
+	assert.Equal(t, []string{"rate limiting", "throttle"}, queries)
+}
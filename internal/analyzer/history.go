@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/git"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// maxHistoryCommits bounds how far back we walk a file's history
+const maxHistoryCommits = 100
+
+// defaultHistoryLimit is how many changes are returned if no limit is given
+const defaultHistoryLimit = 10
+
+// ChunkChange is a commit where a chunk's source changed
+type ChunkChange struct {
+	Commit git.Commit
+	Diff   string // diff of just the chunk between this commit & the previous version
+}
+
+// History is the commits where a chunk's source changed, newest first
+type History struct {
+	ID        string // the chunk walked, e.g., a part's parent or the resolved ID of a misspelled one
+	Changes   []ChunkChange
+	Truncated bool // the history goes further back than what was walked
+}
+
+// ChunkHistory walks the git history of a chunk's file, re-parsing revisions as needed,
+// and returns up to limit commits where the chunk's source changed.
+func (a *Analyzer) ChunkHistory(ctx context.Context, id string, limit int) (*History, error) {
+	return a.chunkHistory(ctx, id, limit, maxHistoryCommits)
+}
+
+func (a *Analyzer) chunkHistory(ctx context.Context, id string, limit int, maxCommits int) (*History, error) {
+	if !strings.Contains(id, "::") {
+		return nil, fmt.Errorf("invalid chunk id: %s", id)
+	}
+
+	id, err := a.historyID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	filePath, chunkPath, _ := strings.Cut(id, "::")
+
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	// One more commit than we walk, so that the oldest walked commit can still be diffed
+	commits, err := git.FileLog(a.workspaceRoot, filePath, maxCommits+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chunk sources as of each commit, "" if the chunk didn't exist, parsed lazily
+	versions := map[int]string{}
+	versionAt := func(i int) (string, error) {
+		if i >= len(commits) {
+			// Before the file's first commit
+			return "", nil
+		}
+
+		version, parsed := versions[i]
+		if parsed {
+			return version, nil
+		}
+
+		version, err := a.chunkSourceAt(commits[i].Hash, commits[i].Path, chunkPath)
+		if err != nil {
+			return "", err
+		}
+		versions[i] = version
+
+		return version, nil
+	}
+
+	history := &History{ID: id}
+	for i := range min(len(commits), maxCommits) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		current, err := versionAt(i)
+		if err != nil {
+			return nil, err
+		}
+
+		previous, err := versionAt(i + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == previous {
+			continue
+		}
+
+		history.Changes = append(history.Changes, ChunkChange{
+			Commit: commits[i],
+			Diff:   diffLines(previous, current),
+		})
+
+		if len(history.Changes) >= limit {
+			return history, nil
+		}
+	}
+
+	history.Truncated = len(commits) > maxCommits
+	return history, nil
+}
+
+// historyID returns the ID of the indexed chunk whose history is requested.
+// Parts are only split off for embedding so they share the history of their chunk.
+func (a *Analyzer) historyID(ctx context.Context, id string) (string, error) {
+	_, err := a.index.GetChunk(ctx, id)
+	if err != nil {
+		resolution := a.resolve(ctx, id, newPackageLookup())
+		if resolution.ID == "" {
+			if len(resolution.Suggestions) > 0 {
+				return "", fmt.Errorf("%w, did you mean: %s", err, strings.Join(resolution.Suggestions, ", "))
+			}
+
+			return "", err
+		}
+
+		id = resolution.ID
+	}
+
+	filePath, chunkPath, _ := strings.Cut(id, "::")
+	parentPath, isPart := parser.PartOf(chunkPath)
+	if isPart {
+		id = filePath + "::" + parentPath
+	}
+
+	return id, nil
+}
+
+// chunkSourceAt returns the source of a chunk as of a specific commit
+func (a *Analyzer) chunkSourceAt(rev, filePath, chunkPath string) (string, error) {
+	source, err := git.Show(a.workspaceRoot, rev, filePath)
+	if err != nil || len(source) == 0 {
+		// The file was deleted (or emptied) in this commit
+		return "", nil
+	}
+
+	file, err := a.parse(filePath, source)
+	if err != nil {
+		return "", err
+	}
+
+	for _, chunk := range file.Chunks {
+		if chunk.Path == chunkPath {
+			return chunk.Source, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHistoryAnalyzer commits each version of cart/cart.go in order to a new repository
+func newHistoryAnalyzer(t *testing.T, versions []string) *Analyzer {
+	t.Helper()
+
+	a := newTestAnalyzer(t, map[string]string{"cart/cart.go": versions[0]})
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = a.workspaceRoot
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	git("init", "-q")
+	for i, version := range versions {
+		filePath := filepath.Join(a.workspaceRoot, "cart", "cart.go")
+		require.NoError(t, os.WriteFile(filePath, []byte(version), 0o644))
+		git("add", "-A")
+		git("commit", "-q", "-m", "version "+string(rune('1'+i)))
+	}
+
+	for filePath, err := range a.ReindexFiles(context.Background(), []string{"cart/cart.go"}) {
+		require.NoError(t, err, filePath)
+	}
+
+	return a
+}
+
+func TestChunkHistory(t *testing.T) {
+	a := newHistoryAnalyzer(t, []string{
+		"package cart\n\nfunc Total() int { return 0 }\n",
+		"package cart\n\nfunc Total() int { return 1 }\n",
+		"package cart\n\nfunc Total() int { return 1 }\n\nfunc Other() {}\n",
+		"package cart\n\nfunc Total() int { return 2 }\n\nfunc Other() {}\n",
+	})
+	ctx := context.Background()
+
+	messages := func(changes []ChunkChange) []string {
+		var messages []string
+		for _, change := range changes {
+			messages = append(messages, change.Commit.Message)
+		}
+
+		return messages
+	}
+
+	tests := []struct {
+		name       string
+		limit      int
+		maxCommits int
+		expected   []string
+		truncated  bool
+	}{
+		{name: "all changes", limit: 10, maxCommits: 100, expected: []string{"version 4", "version 2", "version 1"}},
+		{name: "default limit", limit: 0, maxCommits: 100, expected: []string{"version 4", "version 2", "version 1"}},
+		{name: "limited", limit: 1, maxCommits: 100, expected: []string{"version 4"}},
+		{name: "truncated log", limit: 10, maxCommits: 2, expected: []string{"version 4"}, truncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := a.chunkHistory(ctx, "cart/cart.go::Total", tt.limit, tt.maxCommits)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, messages(history.Changes))
+			assert.Equal(t, tt.truncated, history.Truncated)
+		})
+	}
+
+	// The oldest walked commit is diffed against its parent rather than reported as adding the chunk
+	history, err := a.chunkHistory(ctx, "cart/cart.go::Total", 10, 3)
+	require.NoError(t, err)
+	changes := history.Changes
+	require.Len(t, changes, 2)
+	assert.Equal(t, "version 2", changes[1].Commit.Message)
+	assert.Contains(t, changes[1].Diff, "- func Total() int { return 0 }")
+}
+
+func TestChunkHistoryIDs(t *testing.T) {
+	body := "\t" + strings.ReplaceAll(numberedLines(20), "\n", "\n\t")
+	a := newHistoryAnalyzer(t, []string{
+		"package cart\n\nfunc Total() int {\n\treturn 0\n}\n",
+		"package cart\n\nfunc Total() int {\n\ttotal := 0\n" + body + "\n\treturn total\n}\n",
+	})
+	ctx := context.Background()
+
+	// Split into parts for embedding
+	a.parsersMu.Lock()
+	for _, p := range a.parsers {
+		p.SetMaxChunkTokens(32)
+	}
+	a.parsersMu.Unlock()
+	for filePath, err := range a.ReindexFiles(ctx, []string{"cart/cart.go"}) {
+		require.NoError(t, err, filePath)
+	}
+
+	_, err := a.index.GetChunk(ctx, "cart/cart.go::Total#2")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+		err      string
+	}{
+		{name: "exact", id: "cart/cart.go::Total", expected: "cart/cart.go::Total"},
+		{name: "part", id: "cart/cart.go::Total#2", expected: "cart/cart.go::Total"},
+		{name: "misspelled", id: "cart/cart.go::Totl", expected: "cart/cart.go::Total"},
+		{name: "unknown", id: "cart/cart.go::Checkout", err: "not found"},
+		{name: "invalid", id: "Total", err: "invalid chunk id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := a.chunkHistory(ctx, tt.id, 10, 100)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, history.ID)
+			assert.Len(t, history.Changes, 2)
+		})
+	}
+}
@@ -15,6 +15,7 @@ const (
 	Markdown    Language = "markdown"
 	Python      Language = "python"
 	TypeScript  Language = "typescript"
+	TSX         Language = "tsx"
 	UnknownLang Language = "unknown"
 )
 
@@ -75,7 +76,7 @@ func init() {
 
 	languages.register(
 		JavaScript,
-		[]string{".js", ".jsx", ".mjs"},
+		[]string{".js", ".jsx", ".mjs", ".cjs"},
 		func(workspaceRoot string) (*parser.Parser, error) {
 			return parser.NewJavaScriptParser(workspaceRoot)
 		},
@@ -99,9 +100,17 @@ func init() {
 
 	languages.register(
 		TypeScript,
-		[]string{".ts", ".tsx"},
+		[]string{".ts"},
 		func(workspaceRoot string) (*parser.Parser, error) {
 			return parser.NewTypeScriptParser(workspaceRoot)
 		},
 	)
+
+	languages.register(
+		TSX,
+		[]string{".tsx"},
+		func(workspaceRoot string) (*parser.Parser, error) {
+			return parser.NewTSXParser(workspaceRoot)
+		},
+	)
 }
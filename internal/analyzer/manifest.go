@@ -0,0 +1,195 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/git"
+)
+
+const manifestsDir = ".sourcerer/manifests"
+
+// maxManifests is how many recently indexed commits keep their embeddings cached
+const maxManifests = 5
+
+// manifest records the state of the index for a specific commit so that we can
+// tell which commit the index corresponds to. Chunk vectors are keyed by content
+// hash & the embeddings of the last few commits' chunks are kept, so switching
+// between them only re-embeds content that's truly new.
+type manifest struct {
+	Commit     string    `json:"commit"`
+	Branch     string    `json:"branch,omitempty"`
+	IndexedAt  time.Time `json:"indexedAt"`
+	Embeddings []string  `json:"embeddings"` // content hashes of the embeddings the commit's chunks use
+}
+
+func manifestPath(dir, commit string) string {
+	return filepath.Join(dir, commit+".json")
+}
+
+func loadManifest(dir, commit string) (*manifest, error) {
+	data, err := os.ReadFile(manifestPath(dir, commit))
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifest
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (m *manifest) save(dir string) error {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(manifestPath(dir, m.Commit), data, 0o644)
+}
+
+// pruneManifests deletes all but the keep most recently indexed manifests in dir, returning the kept ones
+func pruneManifests(dir string, keep int) ([]*manifest, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []*manifest
+	for _, entry := range entries {
+		commit, isManifest := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !isManifest {
+			continue
+		}
+
+		m, err := loadManifest(dir, commit)
+		if err != nil {
+			// Unreadable, e.g., written by an older version
+			os.Remove(filepath.Join(dir, entry.Name()))
+			continue
+		}
+
+		manifests = append(manifests, m)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].IndexedAt.After(manifests[j].IndexedAt)
+	})
+
+	for _, m := range manifests[min(len(manifests), keep):] {
+		os.Remove(manifestPath(dir, m.Commit))
+	}
+
+	return manifests[:min(len(manifests), keep)], nil
+}
+
+// updateManifest records the current state of the index against the checked out commit.
+// Listing the index is slow on large workspaces so this only happens on IndexWorkspace & Close,
+// in between manifests are only switched when HEAD moves, see switchManifest.
+func (a *Analyzer) updateManifest(ctx context.Context) {
+	if a.opts.Static {
+		return
+	}
+
+	head, err := git.GetHead(a.workspaceRoot)
+	if err != nil {
+		return
+	}
+
+	a.recordManifest(ctx, head)
+}
+
+func (a *Analyzer) recordManifest(ctx context.Context, head *git.Head) {
+	embeddings, err := a.index.EmbeddingHashes(ctx)
+	if err != nil {
+		return
+	}
+
+	m := &manifest{
+		Commit:     head.Commit,
+		Branch:     head.Branch,
+		IndexedAt:  time.Now(),
+		Embeddings: embeddings,
+	}
+
+	err = m.save(manifestsDir)
+	if err != nil {
+		return
+	}
+
+	a.manifest = m
+}
+
+// switchManifest follows HEAD as changes get indexed, e.g., after a branch switch.
+// Commits that were indexed before swap their stored manifest back in, others are recorded.
+func (a *Analyzer) switchManifest(ctx context.Context) {
+	if a.opts.Static {
+		return
+	}
+
+	head, err := git.GetHead(a.workspaceRoot)
+	if err != nil || (a.manifest != nil && a.manifest.Commit == head.Commit) {
+		return
+	}
+
+	m := checkoutManifest(manifestsDir, head)
+	if m == nil {
+		a.recordManifest(ctx, head)
+		return
+	}
+
+	a.manifest = m
+}
+
+// checkoutManifest returns the stored manifest of a checked out commit, marking it as the
+// most recently indexed one, or nil if the commit wasn't indexed before
+func checkoutManifest(dir string, head *git.Head) *manifest {
+	m, err := loadManifest(dir, head.Commit)
+	if err != nil {
+		return nil
+	}
+
+	m.Branch = head.Branch
+	m.IndexedAt = time.Now()
+	err = m.save(dir)
+	if err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// pruneEmbeddings evicts cached embeddings that neither the index nor the most
+// recently indexed commits use, so that the cache doesn't grow forever
+func (a *Analyzer) pruneEmbeddings(ctx context.Context) {
+	if a.opts.Static {
+		return
+	}
+
+	manifests, err := pruneManifests(manifestsDir, maxManifests)
+	if err != nil {
+		return
+	}
+
+	keep := map[string]bool{}
+	for _, m := range manifests {
+		for _, hash := range m.Embeddings {
+			keep[hash] = true
+		}
+	}
+
+	a.index.PruneEmbeddings(ctx, keep)
+}
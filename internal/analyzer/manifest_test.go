@@ -0,0 +1,61 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneManifests(t *testing.T) {
+	dir := t.TempDir()
+
+	now := time.Now()
+	for i, commit := range []string{"a", "b", "c", "d"} {
+		m := &manifest{Commit: commit, IndexedAt: now.Add(time.Duration(i) * time.Minute)}
+		require.NoError(t, m.save(dir))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "old.json"), []byte("not json"), 0o644))
+
+	kept, err := pruneManifests(dir, 2)
+	require.NoError(t, err)
+
+	commits := []string{}
+	for _, m := range kept {
+		commits = append(commits, m.Commit)
+	}
+	assert.Equal(t, []string{"d", "c"}, commits)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"c.json", "d.json"}, names)
+}
+
+func TestCheckoutManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	indexedAt := time.Now().Add(-time.Hour)
+	stored := &manifest{Commit: "a", Branch: "main", IndexedAt: indexedAt, Embeddings: []string{"x", "y"}}
+	require.NoError(t, stored.save(dir))
+
+	m := checkoutManifest(dir, &git.Head{Commit: "a", Branch: "feature"})
+	require.NotNil(t, m)
+	assert.Equal(t, []string{"x", "y"}, m.Embeddings)
+	assert.Equal(t, "feature", m.Branch)
+	assert.True(t, m.IndexedAt.After(indexedAt))
+
+	// Kept as the most recently indexed one
+	saved, err := loadManifest(dir, "a")
+	require.NoError(t, err)
+	assert.Equal(t, m.IndexedAt.Unix(), saved.IndexedAt.Unix())
+
+	assert.Nil(t, checkoutManifest(dir, &git.Head{Commit: "b", Branch: "main"}))
+}
@@ -0,0 +1,286 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+const maxTestLinks = 20
+
+// Evidence linking tests to code, a link needs a name match or a reference from a related file
+const (
+	namedExactlyScore = 4 // e.g., TestFindUser tests FindUser
+	namedScore        = 3 // e.g., TestFindUserMissing tests FindUser
+	referenceScore    = 2 // the test mentions the code's name
+	relatedFileScore  = 1 // e.g., cart_test.go & cart.go, or the test file imports the code's module
+	minLinkScore      = 3
+)
+
+// testPrefixes & testSuffixes mark test names, e.g., TestFoo, test_foo, BenchmarkFoo, FooTest
+var (
+	testPrefixes = []string{"test", "tests", "benchmark", "fuzz", "example"}
+	testSuffixes = []string{"test", "tests", "spec"}
+)
+
+// testFileMarkers are stripped from test file names to find the file they test
+var testFileMarkers = []string{"_test", ".test", ".spec", "_spec", "test_"}
+
+var identifierPattern = regexp.MustCompile(`[\p{L}_$][\p{L}\p{N}_$]*`)
+
+// TestLink is a chunk linked to a test or to the code it tests, with why
+type TestLink struct {
+	Chunk   *parser.Chunk
+	Reasons []string
+	score   int
+}
+
+// FindTests finds the test chunks that test a source chunk, by name conventions
+// (e.g., TestFoo & test_foo), references, and imports
+func (a *Analyzer) FindTests(ctx context.Context, chunkID string) ([]TestLink, error) {
+	target, err := a.index.GetChunk(ctx, chunkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Type == string(parser.FileTypeTests) {
+		return nil, fmt.Errorf("%s is a test, use find_tested_code to find the code it tests", chunkID)
+	}
+
+	tests, err := a.index.NamedChunks(ctx, string(parser.FileTypeTests))
+	if err != nil {
+		return nil, err
+	}
+
+	imports := map[string]string{}
+	var links []TestLink
+	for _, test := range tests {
+		if !sameLanguageFamily(test.Language, target.Language) {
+			continue
+		}
+
+		link := a.linkTest(test, target, imports)
+		if link.score >= minLinkScore {
+			link.Chunk = test
+			links = append(links, link)
+		}
+	}
+
+	return mostSpecificLinks(links), nil
+}
+
+// FindTestedCode finds the source chunks that a test chunk tests, the inverse of FindTests
+func (a *Analyzer) FindTestedCode(ctx context.Context, testID string) ([]TestLink, error) {
+	test, err := a.index.GetChunk(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	if test.Type != string(parser.FileTypeTests) {
+		return nil, fmt.Errorf("%s isn't a test, use find_tests to find its tests", testID)
+	}
+
+	sources, err := a.index.NamedChunks(ctx, string(parser.FileTypeSrc))
+	if err != nil {
+		return nil, err
+	}
+
+	imports := map[string]string{}
+	var links []TestLink
+	for _, source := range sources {
+		if !sameLanguageFamily(test.Language, source.Language) {
+			continue
+		}
+
+		link := a.linkTest(test, source, imports)
+		if link.score >= minLinkScore {
+			link.Chunk = source
+			links = append(links, link)
+		}
+	}
+
+	sortLinks(links)
+	return links[:min(len(links), maxTestLinks)], nil
+}
+
+// linkTest weighs the evidence that test tests target. The imports of test files
+// are cached in imports since they're only parsed when needed.
+func (a *Analyzer) linkTest(test, target *parser.Chunk, imports map[string]string) TestLink {
+	var link TestLink
+
+	exact, named := namesSubject(testSubject(test.Name), target)
+	switch {
+	case exact:
+		link.score += namedExactlyScore
+		link.Reasons = append(link.Reasons, "named after it")
+	case named:
+		link.score += namedScore
+		link.Reasons = append(link.Reasons, "named after it")
+	}
+
+	if len(target.Name) >= 3 && mentions(test.Source, target.Name) {
+		link.score += referenceScore
+		link.Reasons = append(link.Reasons, "references "+target.Name)
+	}
+
+	// Only worth checking if it would tip the balance
+	if link.score == 0 || link.score >= minLinkScore {
+		return link
+	}
+
+	fileImports, cached := imports[test.File]
+	if !cached {
+		fileImports = a.fileImports(test.File)
+		imports[test.File] = fileImports
+	}
+
+	reason := relatedFile(test.File, target, fileImports)
+	if reason != "" {
+		link.score += relatedFileScore
+		link.Reasons = append(link.Reasons, reason)
+	}
+
+	return link
+}
+
+// testSubject returns the words naming what a test tests, e.g., TestService_FindUser -> service, find, user
+func testSubject(name string) []string {
+	words := parser.SplitIdentifier(name)
+	for _, prefix := range testPrefixes {
+		if len(words) > 1 && words[0] == prefix {
+			words = words[1:]
+			break
+		}
+	}
+
+	for _, suffix := range testSuffixes {
+		if len(words) > 1 && words[len(words)-1] == suffix {
+			words = words[:len(words)-1]
+			break
+		}
+	}
+
+	return words
+}
+
+// namesSubject reports whether a test's subject names a chunk, either by its name or
+// qualified by its parents, e.g., Service::FindUser -> TestFindUser & TestService_FindUser.
+// Subjects can go on to describe a scenario, e.g., test_find_user_missing.
+func namesSubject(subject []string, chunk *parser.Chunk) (exact, ok bool) {
+	candidates := [][]string{parser.SplitIdentifier(chunk.Name)}
+	if strings.Contains(chunk.Path, "::") {
+		candidates = append(candidates, parser.SplitIdentifier(chunk.Path))
+	}
+
+	for _, words := range candidates {
+		if len(words) == 0 || len(words) > len(subject) {
+			continue
+		}
+
+		if strings.Join(subject[:len(words)], " ") == strings.Join(words, " ") {
+			exact = exact || len(words) == len(subject)
+			ok = true
+		}
+	}
+
+	return exact, ok
+}
+
+// mentions reports whether source contains name as a whole identifier
+func mentions(source, name string) bool {
+	if !strings.Contains(source, name) {
+		return false
+	}
+
+	for _, identifier := range identifierPattern.FindAllString(source, -1) {
+		if identifier == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// relatedFile explains how a test file relates to a chunk's file, e.g., cart_test.go tests cart.go,
+// Go tests share their package's directory, and other tests import the chunk's module
+func relatedFile(testFile string, chunk *parser.Chunk, imports string) string {
+	testDir, testBase := path.Split(testFile)
+	chunkDir, chunkBase := path.Split(chunk.File)
+
+	testName := strings.TrimSuffix(testBase, path.Ext(testBase))
+	for _, marker := range testFileMarkers {
+		testName = strings.Replace(testName, marker, "", 1)
+	}
+
+	chunkName := strings.TrimSuffix(chunkBase, path.Ext(chunkBase))
+	if testName == chunkName && (testDir == chunkDir || path.Base(testDir) == "tests") {
+		return "tests its file"
+	}
+
+	// Go tests in the same directory are in the same package & don't import it
+	module := chunkName
+	if chunk.Language == "go" {
+		if testDir == chunkDir {
+			return "same package"
+		}
+
+		module = path.Base(path.Clean(chunkDir))
+	}
+
+	pattern := regexp.MustCompile(`(^|[\s/.'"])` + regexp.QuoteMeta(module) + `($|[\s/.'",;])`)
+	if pattern.MatchString(imports) {
+		return "imports its module"
+	}
+
+	return ""
+}
+
+// sameLanguageFamily allows, e.g., TypeScript tests for JavaScript code
+func sameLanguageFamily(a, b string) bool {
+	family := func(language string) string {
+		if language == "typescript" {
+			return "javascript"
+		}
+
+		return language
+	}
+
+	return family(a) == family(b)
+}
+
+// mostSpecificLinks drops links to chunks enclosing other linked chunks, e.g., a test class
+// when one of its methods is linked too, & sorts the rest
+func mostSpecificLinks(links []TestLink) []TestLink {
+	var specific []TestLink
+	for _, link := range links {
+		enclosing := false
+		for _, other := range links {
+			if other.Chunk.File == link.Chunk.File && strings.HasPrefix(other.Chunk.Path, link.Chunk.Path+"::") {
+				enclosing = true
+				break
+			}
+		}
+
+		if !enclosing {
+			specific = append(specific, link)
+		}
+	}
+
+	sortLinks(specific)
+	return specific[:min(len(specific), maxTestLinks)]
+}
+
+func sortLinks(links []TestLink) {
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].score != links[j].score {
+			return links[i].score > links[j].score
+		}
+
+		return links[i].Chunk.ID() < links[j].Chunk.ID()
+	})
+}
@@ -0,0 +1,161 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLinkFiles = map[string]string{
+	"cart/cart.go": `package cart
+
+// Cart holds the items being bought
+type Cart struct {
+	Items []string
+}
+
+// Add puts an item in the cart
+func (c *Cart) Add(item string) {
+	c.Items = append(c.Items, item)
+}
+
+// Total counts the items in the cart
+func Total(c *Cart) int {
+	return len(c.Items)
+}
+`,
+	"cart/cart_test.go": `package cart
+
+import "testing"
+
+func TestCart_Add(t *testing.T) {
+	c := &Cart{}
+	c.Add("apple")
+}
+
+func TestTotalEmpty(t *testing.T) {
+	if Total(&Cart{}) != 0 {
+		t.Fail()
+	}
+}
+
+func TestUnrelated(t *testing.T) {}
+`,
+	"shop/pricing.py": `def apply_discount(price, percent):
+    """Reduces a price by a percentage."""
+    return price * (100 - percent) / 100
+
+
+def format_price(price):
+    """Formats a price for display."""
+    return f"${price:.2f}"
+`,
+	"tests/test_pricing.py": `from shop.pricing import apply_discount, format_price
+
+
+class TestPricing:
+    def test_apply_discount(self):
+        assert apply_discount(100, 10) == 90
+
+    def test_rounding(self):
+        assert format_price(1) == "$1.00"
+`,
+}
+
+func newTestAnalyzer(t *testing.T, files map[string]string) *Analyzer {
+	t.Helper()
+
+	workspaceRoot := t.TempDir()
+	for filePath, source := range files {
+		fullPath := filepath.Join(workspaceRoot, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(source), 0o644))
+	}
+
+	ctx := context.Background()
+	a, err := New(ctx, workspaceRoot, Options{
+		Index: index.Options{
+			DBPath:   filepath.Join(t.TempDir(), "db"),
+			Embedder: index.NewHashingEmbedder(index.DefaultHashingDimensions),
+		},
+		Static: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(a.Close)
+
+	a.IndexWorkspace(ctx)
+	return a
+}
+
+func linkIDs(links []TestLink) []string {
+	ids := make([]string, len(links))
+	for i, link := range links {
+		ids[i] = link.Chunk.ID()
+	}
+
+	return ids
+}
+
+func TestFindTests(t *testing.T) {
+	a := newTestAnalyzer(t, testLinkFiles)
+
+	tests := []struct {
+		name     string
+		id       string
+		expected []string
+	}{
+		{
+			name:     "go method by type & name",
+			id:       "cart/cart.go::Cart::Add",
+			expected: []string{"cart/cart_test.go::TestCart_Add"},
+		},
+		{
+			name:     "go function with a scenario suffix",
+			id:       "cart/cart.go::Total",
+			expected: []string{"cart/cart_test.go::TestTotalEmpty"},
+		},
+		{
+			name:     "python function by name",
+			id:       "shop/pricing.py::apply_discount",
+			expected: []string{"tests/test_pricing.py::TestPricing::test_apply_discount"},
+		},
+		{
+			name:     "python function by reference from an importing test",
+			id:       "shop/pricing.py::format_price",
+			expected: []string{"tests/test_pricing.py::TestPricing::test_rounding"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links, err := a.FindTests(context.Background(), tt.id)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, linkIDs(links))
+		})
+	}
+
+	_, err := a.FindTests(context.Background(), "cart/cart_test.go::TestCart_Add")
+	assert.Error(t, err)
+}
+
+func TestFindTestedCode(t *testing.T) {
+	a := newTestAnalyzer(t, testLinkFiles)
+
+	links, err := a.FindTestedCode(context.Background(), "cart/cart_test.go::TestCart_Add")
+	require.NoError(t, err)
+	require.NotEmpty(t, links)
+	assert.Equal(t, "cart/cart.go::Cart::Add", links[0].Chunk.ID())
+	assert.Contains(t, links[0].Reasons, "named after it")
+
+	links, err = a.FindTestedCode(context.Background(), "cart/cart_test.go::TestUnrelated")
+	require.NoError(t, err)
+	assert.Empty(t, links)
+
+	_, err = a.FindTestedCode(context.Background(), "cart/cart.go::Total")
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package eval
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultK = 5
+
+// Dataset is a set of queries & the chunks expected to be retrieved for them
+type Dataset struct {
+	Workspace  string  `yaml:"workspace"`  // workspace to index, relative paths are resolved against the dataset file
+	K          int     `yaml:"k"`          // rank cutoff for the metrics
+	Thresholds Metrics `yaml:"thresholds"` // minimum mean metrics, e.g., to guard against regressions
+	Cases      []Case  `yaml:"queries"`
+}
+
+// Case is a query & the IDs of the chunks relevant to it
+type Case struct {
+	Query     string   `yaml:"query"`
+	Expected  []string `yaml:"expected"`
+	FileTypes []string `yaml:"file_types,omitempty"`
+}
+
+// LoadDataset reads a YAML dataset
+func LoadDataset(path string) (*Dataset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dataset := &Dataset{Workspace: "."}
+	err = yaml.Unmarshal(data, dataset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dataset %s: %w", path, err)
+	}
+
+	if dataset.K <= 0 {
+		dataset.K = defaultK
+	}
+
+	for i, c := range dataset.Cases {
+		if c.Query == "" || len(c.Expected) == 0 {
+			return nil, fmt.Errorf("invalid dataset %s: query %d needs a query & expected chunk IDs", path, i+1)
+		}
+	}
+
+	return dataset, nil
+}
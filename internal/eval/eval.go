@@ -0,0 +1,151 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/philippgille/chromem-go"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/analyzer"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/reranker"
+)
+
+// Options configures an evaluation run, zero values fall back to defaults
+type Options struct {
+	K        int                   // overrides the dataset's rank cutoff
+	Embedder chromem.EmbeddingFunc // defaults to the offline hashing embedder
+	Expand   bool                  // expands queries with related identifiers
+	// Reranker creates the reranker to evaluate for the dataset's workspace, if any
+	Reranker func(workspaceRoot string) reranker.Reranker
+}
+
+// CaseResult is the outcome of a single query
+type CaseResult struct {
+	Case
+	Ranked  []string // IDs of the retrieved chunks, most relevant first
+	Metrics Metrics
+}
+
+// Report summarizes an evaluation run
+type Report struct {
+	K          int
+	Cases      []CaseResult
+	Mean       Metrics
+	Thresholds Metrics
+}
+
+// Run indexes the dataset's workspace into a throwaway vector db & scores the search
+// results of each query
+func Run(ctx context.Context, datasetPath string, opts Options) (*Report, error) {
+	dataset, err := LoadDataset(datasetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	k := dataset.K
+	if opts.K > 0 {
+		k = opts.K
+	}
+
+	embedder := opts.Embedder
+	if embedder == nil {
+		embedder = index.NewHashingEmbedder(index.DefaultHashingDimensions)
+	}
+
+	dbPath, err := os.MkdirTemp("", "sourcerer-eval-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dbPath)
+
+	workspaceRoot := dataset.Workspace
+	if !filepath.IsAbs(workspaceRoot) {
+		workspaceRoot = filepath.Join(filepath.Dir(datasetPath), workspaceRoot)
+	}
+
+	analyzerOpts := analyzer.Options{
+		Index:  index.Options{DBPath: dbPath, Embedder: embedder},
+		Static: true,
+	}
+	if opts.Reranker != nil {
+		analyzerOpts.Reranker = opts.Reranker(workspaceRoot)
+	}
+
+	a, err := analyzer.New(ctx, workspaceRoot, analyzerOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer a.Close()
+
+	a.IndexWorkspace(ctx)
+
+	report := &Report{K: k, Thresholds: dataset.Thresholds}
+	metrics := make([]Metrics, 0, len(dataset.Cases))
+	for _, c := range dataset.Cases {
+		results, err := a.Search(ctx, c.Query, analyzer.SearchOptions{FileTypes: c.FileTypes, Expand: opts.Expand})
+		if err != nil {
+			return nil, fmt.Errorf("search for %q failed: %w", c.Query, err)
+		}
+
+		ranked := make([]string, len(results))
+		for i, result := range results {
+			ranked[i] = result.Chunk.ID()
+		}
+
+		result := CaseResult{Case: c, Ranked: ranked, Metrics: score(ranked, c.Expected, k)}
+		report.Cases = append(report.Cases, result)
+		metrics = append(metrics, result.Metrics)
+	}
+	report.Mean = mean(metrics)
+
+	return report, nil
+}
+
+// Failures lists the mean metrics that are below the dataset's thresholds
+func (r *Report) Failures() []string {
+	var failures []string
+	check := func(name string, value, threshold float64) {
+		if value < threshold {
+			failures = append(failures, fmt.Sprintf("%s@%d %.3f < %.3f", name, r.K, value, threshold))
+		}
+	}
+
+	check("recall", r.Mean.Recall, r.Thresholds.Recall)
+	check("mrr", r.Mean.MRR, r.Thresholds.MRR)
+	check("ndcg", r.Mean.NDCG, r.Thresholds.NDCG)
+
+	return failures
+}
+
+// String renders the report, including the misses of each query if verbose
+func (r *Report) String(verbose bool) string {
+	var b strings.Builder
+	for _, c := range r.Cases {
+		fmt.Fprintf(
+			&b, "recall %.2f | mrr %.2f | ndcg %.2f | %s\n",
+			c.Metrics.Recall, c.Metrics.MRR, c.Metrics.NDCG, c.Query,
+		)
+
+		if !verbose {
+			continue
+		}
+
+		top := c.Ranked[:min(r.K, len(c.Ranked))]
+		for _, id := range c.Expected {
+			if !slices.Contains(top, id) {
+				fmt.Fprintf(&b, "    missed %s\n", id)
+			}
+		}
+	}
+
+	fmt.Fprintf(
+		&b, "\n%d queries | recall@%d %.3f | mrr@%d %.3f | ndcg@%d %.3f\n",
+		len(r.Cases), r.K, r.Mean.Recall, r.K, r.Mean.MRR, r.K, r.Mean.NDCG,
+	)
+
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		ranked   []string
+		expected []string
+		k        int
+		want     Metrics
+	}{
+		{
+			name:     "FirstHit",
+			ranked:   []string{"a", "b", "c"},
+			expected: []string{"a"},
+			k:        5,
+			want:     Metrics{Recall: 1, MRR: 1, NDCG: 1},
+		},
+		{
+			name:     "SecondHit",
+			ranked:   []string{"b", "a", "c"},
+			expected: []string{"a"},
+			k:        5,
+			want:     Metrics{Recall: 1, MRR: 0.5, NDCG: 0.6309},
+		},
+		{
+			name:     "BeyondCutoff",
+			ranked:   []string{"b", "c", "a"},
+			expected: []string{"a"},
+			k:        2,
+			want:     Metrics{},
+		},
+		{
+			name:     "PartialRecall",
+			ranked:   []string{"a", "c"},
+			expected: []string{"a", "b"},
+			k:        5,
+			want:     Metrics{Recall: 0.5, MRR: 1, NDCG: 0.6131},
+		},
+		{
+			name:     "NoResults",
+			expected: []string{"a"},
+			k:        5,
+			want:     Metrics{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := score(tt.ranked, tt.expected, tt.k)
+			assert.InDelta(t, tt.want.Recall, got.Recall, 1e-4)
+			assert.InDelta(t, tt.want.MRR, got.MRR, 1e-4)
+			assert.InDelta(t, tt.want.NDCG, got.NDCG, 1e-4)
+		})
+	}
+}
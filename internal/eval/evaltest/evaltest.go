@@ -0,0 +1,32 @@
+// Package evaltest runs retrieval evaluations from Go tests, keeping the testing
+// package out of the binaries that import eval
+package evaltest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/eval"
+)
+
+// Require evaluates a dataset & fails the test if any mean metric is below
+// the dataset's thresholds
+func Require(t testing.TB, datasetPath string, opts eval.Options) *eval.Report {
+	t.Helper()
+
+	report, err := eval.Run(context.Background(), datasetPath, opts)
+	if err != nil {
+		t.Fatalf("evaluation of %s failed: %v", datasetPath, err)
+	}
+
+	failures := report.Failures()
+	if len(failures) > 0 {
+		t.Fatalf(
+			"retrieval quality regressed on %s: %s\n\n%s",
+			datasetPath, strings.Join(failures, ", "), report.String(true),
+		)
+	}
+
+	return report
+}
@@ -0,0 +1,15 @@
+package evaltest
+
+import (
+	"testing"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/eval"
+)
+
+func TestFixtureDataset(t *testing.T) {
+	Require(t, "../../../testdata/eval.yaml", eval.Options{})
+}
+
+func TestFixtureDatasetExpanded(t *testing.T) {
+	Require(t, "../../../testdata/eval.yaml", eval.Options{Expand: true})
+}
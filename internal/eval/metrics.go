@@ -0,0 +1,60 @@
+package eval
+
+import "math"
+
+// Metrics are retrieval quality metrics at a rank cutoff k, between 0 & 1
+type Metrics struct {
+	Recall float64 `yaml:"recall"` // share of the expected chunks in the top k
+	MRR    float64 `yaml:"mrr"`    // reciprocal rank of the first expected chunk in the top k
+	NDCG   float64 `yaml:"ndcg"`   // normalized discounted cumulative gain of the top k
+}
+
+// score computes the metrics of ranked chunk IDs against the expected ones
+func score(ranked, expected []string, k int) Metrics {
+	relevant := make(map[string]bool, len(expected))
+	for _, id := range expected {
+		relevant[id] = true
+	}
+
+	var metrics Metrics
+	var hits int
+	var dcg float64
+	for i, id := range ranked[:min(k, len(ranked))] {
+		if !relevant[id] {
+			continue
+		}
+
+		hits++
+		dcg += 1 / math.Log2(float64(i+2))
+		if metrics.MRR == 0 {
+			metrics.MRR = 1 / float64(i+1)
+		}
+	}
+
+	var idealDCG float64
+	for i := range min(k, len(expected)) {
+		idealDCG += 1 / math.Log2(float64(i+2))
+	}
+
+	metrics.Recall = float64(hits) / float64(len(expected))
+	metrics.NDCG = dcg / idealDCG
+
+	return metrics
+}
+
+// mean averages metrics
+func mean(metrics []Metrics) Metrics {
+	var sum Metrics
+	if len(metrics) == 0 {
+		return sum
+	}
+
+	for _, m := range metrics {
+		sum.Recall += m.Recall
+		sum.MRR += m.MRR
+		sum.NDCG += m.NDCG
+	}
+
+	n := float64(len(metrics))
+	return Metrics{Recall: sum.Recall / n, MRR: sum.MRR / n, NDCG: sum.NDCG / n}
+}
@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type FileFilter struct {
@@ -29,12 +30,16 @@ func (f *FileFilter) ShouldIgnore(path string) bool {
 		return true
 	}
 
+	return !f.isSupported(path)
+}
+
+func (f *FileFilter) isSupported(path string) bool {
 	ext := strings.ToLower(filepath.Ext(path))
-	if ext == "" {
-		return false
-	}
+	return ext == "" || f.supportedExts[ext]
+}
 
-	return !f.supportedExts[ext]
+func (f *FileFilter) ShouldIgnoreDir(path string) bool {
+	return f.isGitIgnored(path)
 }
 
 func (f *FileFilter) isGitIgnored(path string) bool {
@@ -48,19 +53,106 @@ func (f *FileFilter) isGitIgnored(path string) bool {
 	return cmd.Run() == nil
 }
 
-func WalkSourceFiles(workspaceRoot string, supportedExts []string, callback func(filePath string) error) error {
-	filter := NewFileFilter(workspaceRoot, supportedExts)
+// gitIgnored returns which of the paths are ignored, checking them all with a single git process
+func (f *FileFilter) gitIgnored(paths []string) map[string]bool {
+	ignored := map[string]bool{}
+	if len(paths) == 0 {
+		return ignored
+	}
+
+	cmd := exec.Command("git", "check-ignore", "-z", "--stdin")
+	cmd.Dir = f.workspaceRoot
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
 
-	return filepath.Walk(workspaceRoot, func(path string, info os.FileInfo, err error) error {
+	// git exits with 1 when none of the paths are ignored
+	output, _ := cmd.Output()
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			ignored[path] = true
+		}
+	}
+
+	return ignored
+}
+
+// dirListing is what a directory contains as of its modification time
+type dirListing struct {
+	modTime time.Time
+	dirs    []string // subdirectories that aren't ignored
+	files   []string // supported source files that aren't ignored
+}
+
+// list reads a directory, checking its entries against .gitignore in one go
+func (f *FileFilter) list(dir string) (dirListing, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return dirListing{}, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return dirListing{}, err
+	}
+
+	paths := make([]string, 0, len(entries))
+	isDir := map[string]bool{}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.Name() == ".git" || (!entry.IsDir() && !f.isSupported(path)) {
+			continue
+		}
+
+		paths = append(paths, path)
+		isDir[path] = entry.IsDir()
+	}
+
+	listing := dirListing{modTime: info.ModTime()}
+	ignored := f.gitIgnored(paths)
+	for _, path := range paths {
+		if ignored[path] {
+			continue
+		}
+
+		if isDir[path] {
+			listing.dirs = append(listing.dirs, path)
+		} else {
+			listing.files = append(listing.files, path)
+		}
+	}
+
+	return listing, nil
+}
+
+// walk visits every directory & supported source file under root,
+// skipping ignored directories entirely
+func (f *FileFilter) walk(root string, callback func(path string, info os.FileInfo) error) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
+			// Paths can disappear mid-walk, e.g. during a branch switch
+			if os.IsNotExist(err) {
+				return nil
+			}
+
 			return err
 		}
 
-		if filter.ShouldIgnore(path) {
-			if info.IsDir() {
+		if info.IsDir() {
+			if f.ShouldIgnoreDir(path) {
 				return filepath.SkipDir
 			}
+		} else if f.ShouldIgnore(path) {
+			return nil
+		}
+
+		return callback(path, info)
+	})
+}
+
+func WalkSourceFiles(workspaceRoot string, supportedExts []string, callback func(filePath string) error) error {
+	filter := NewFileFilter(workspaceRoot, supportedExts)
 
+	return filter.walk(workspaceRoot, func(path string, info os.FileInfo) error {
+		if info.IsDir() {
 			return nil
 		}
 
@@ -2,15 +2,28 @@ package fs
 
 import (
 	"context"
+	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
 
 const (
-	debounceDuration = 60 * time.Second
+	// Small bursts of changes (e.g., saving a file) are indexed quickly...
+	debounceDuration = 2 * time.Second
+	// ...while bulk changes (e.g., branch switches) wait for things to settle
+	bulkDebounceDuration = 30 * time.Second
+	bulkChangeThreshold  = 20
+	// Continuous edits still get indexed at least this often
+	maxDebounceWait = 2 * time.Minute
+	// rescanInterval is how often the workspace is rescanned once
+	// the OS watch limit (e.g., fs.inotify.max_user_watches) is hit
+	rescanInterval = 2 * time.Minute
 )
 
 type FileChangeHandler func(ctx context.Context, filePaths []string)
@@ -22,11 +35,19 @@ type Watcher struct {
 	fsWatcher        *fsnotify.Watcher
 	debounceTimer    *time.Timer
 	pendingFiles     map[string]bool
+	firstPendingAt   time.Time
 	mu               sync.RWMutex
 	debounceDuration time.Duration
 	ctx              context.Context
 	cancel           context.CancelFunc
 
+	watchedDirs map[string]bool // absolute paths of watched directories
+	addWatch    func(dir string) error
+	polling     bool // whether we've fallen back to periodic rescans
+	snapshot    map[string]time.Time
+	listings    map[string]dirListing // directories as of the last scan, reused until they change
+	dirsMu      sync.Mutex
+
 	initOnce sync.Once
 	initErr  error
 }
@@ -47,6 +68,8 @@ func NewWatcher(ctx context.Context, workspaceRoot string, supportedExts []strin
 		debounceDuration: debounceDuration,
 		ctx:              ctx,
 		cancel:           cancel,
+		watchedDirs:      map[string]bool{},
+		addWatch:         fsWatcher.Add,
 	}
 
 	go w.ensureInitialized()
@@ -58,36 +81,181 @@ func NewWatcher(ctx context.Context, workspaceRoot string, supportedExts []strin
 
 func (w *Watcher) ensureInitialized() error {
 	w.initOnce.Do(func() {
-		w.initErr = w.addWatchers()
+		_, w.initErr = w.watchTree(w.workspaceRoot)
 	})
 
 	return w.initErr
 }
 
-func (w *Watcher) addWatchers() error {
-	var supportedExts []string
-	for ext := range w.filter.supportedExts {
-		supportedExts = append(supportedExts, ext)
+// watchTree recursively watches root & its subdirectories,
+// returning the source files found along the way
+func (w *Watcher) watchTree(root string) ([]string, error) {
+	var files []string
+	err := w.filter.walk(root, func(path string, info os.FileInfo) error {
+		if !info.IsDir() {
+			files = append(files, path)
+			return nil
+		}
+
+		return w.addDir(path)
+	})
+
+	return files, err
+}
+
+func (w *Watcher) addDir(dir string) error {
+	w.dirsMu.Lock()
+	defer w.dirsMu.Unlock()
+
+	if w.watchedDirs[dir] || w.polling {
+		return nil
 	}
 
-	uniqueDirs := make(map[string]bool)
-	err := WalkSourceFiles(w.workspaceRoot, supportedExts, func(filePath string) error {
-		dir := filepath.Dir(filepath.Join(w.workspaceRoot, filePath))
-		uniqueDirs[dir] = true
+	err := w.addWatch(dir)
+	if errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE) {
+		// Out of watches, the periodic rescans will cover the whole workspace
+		w.startPolling()
 		return nil
-	})
+	}
+
 	if err != nil {
+		// The directory might have been removed before we got to it
+		if os.IsNotExist(err) {
+			return nil
+		}
+
 		return err
 	}
 
-	for dir := range uniqueDirs {
-		err := w.fsWatcher.Add(dir)
+	w.watchedDirs[dir] = true
+	return nil
+}
+
+func (w *Watcher) removeDir(dir string) {
+	w.dirsMu.Lock()
+	defer w.dirsMu.Unlock()
+
+	prefix := dir + string(filepath.Separator)
+	for watched := range w.watchedDirs {
+		if watched != dir && !strings.HasPrefix(watched, prefix) {
+			continue
+		}
+
+		// Watches on deleted directories are dropped automatically so
+		// errors here are expected & harmless
+		w.fsWatcher.Remove(watched)
+		delete(w.watchedDirs, watched)
+	}
+}
+
+func (w *Watcher) isWatchedDir(dir string) bool {
+	w.dirsMu.Lock()
+	defer w.dirsMu.Unlock()
+
+	return w.watchedDirs[dir]
+}
+
+// startPolling must be called with dirsMu held
+func (w *Watcher) startPolling() {
+	if w.polling {
+		return
+	}
+
+	w.polling = true
+	go w.poll()
+}
+
+func (w *Watcher) poll() {
+	snapshot := w.scan()
+
+	w.dirsMu.Lock()
+	w.snapshot = snapshot
+	w.dirsMu.Unlock()
+
+	ticker := time.NewTicker(rescanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.rescan()
+		}
+	}
+}
+
+// scan records the modification times of all source files in the workspace.
+// Only directories that changed since the last scan are checked against .gitignore
+// since large workspaces, the ones that run out of watches, have lots of files.
+func (w *Watcher) scan() map[string]time.Time {
+	snapshot := map[string]time.Time{}
+	listings := map[string]dirListing{}
+	w.scanDir(w.workspaceRoot, snapshot, listings)
+
+	w.dirsMu.Lock()
+	w.listings = listings
+	w.dirsMu.Unlock()
+
+	return snapshot
+}
+
+func (w *Watcher) scanDir(dir string, snapshot map[string]time.Time, listings map[string]dirListing) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		// Paths can disappear mid-scan, e.g. during a branch switch
+		return
+	}
+
+	w.dirsMu.Lock()
+	listing, cached := w.listings[dir]
+	w.dirsMu.Unlock()
+
+	if !cached || !listing.modTime.Equal(info.ModTime()) {
+		listing, err = w.filter.list(dir)
 		if err != nil {
-			return err
+			return
 		}
 	}
+	listings[dir] = listing
 
-	return nil
+	for _, path := range listing.files {
+		fileInfo, err := os.Stat(path)
+		if err == nil {
+			snapshot[path] = fileInfo.ModTime()
+		}
+	}
+
+	for _, subdir := range listing.dirs {
+		w.scanDir(subdir, snapshot, listings)
+	}
+}
+
+// rescan queues files that were created, modified, or deleted since the last scan
+func (w *Watcher) rescan() {
+	current := w.scan()
+
+	w.dirsMu.Lock()
+	previous := w.snapshot
+	w.snapshot = current
+	w.dirsMu.Unlock()
+
+	var changes []string
+	for path, modTime := range current {
+		prevModTime, exists := previous[path]
+		if !exists || !modTime.Equal(prevModTime) {
+			changes = append(changes, path)
+		}
+	}
+
+	for path := range previous {
+		_, exists := current[path]
+		if !exists {
+			changes = append(changes, path)
+		}
+	}
+
+	w.queue(changes...)
 }
 
 func (w *Watcher) watch() {
@@ -110,60 +278,163 @@ func (w *Watcher) watch() {
 }
 
 func (w *Watcher) handleEvent(event fsnotify.Event) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+		return
+	}
+
+	if event.Has(fsnotify.Create) {
+		info, err := os.Stat(event.Name)
+		if err == nil && info.IsDir() {
+			w.handleDirCreated(event.Name)
+			return
+		}
+	}
+
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		if w.isWatchedDir(event.Name) {
+			w.handleDirRemoved(event.Name)
+			return
+		}
+	}
+
+	if w.filter.ShouldIgnore(event.Name) {
+		return
+	}
+
+	w.queue(event.Name)
+}
 
-	if w.shouldIgnoreEvent(event) {
+// handleDirCreated watches a new directory (including ones renamed into place)
+// and queues any files that were written before the watch was added
+func (w *Watcher) handleDirCreated(dir string) {
+	if w.filter.ShouldIgnoreDir(dir) {
 		return
 	}
 
-	relPath, err := filepath.Rel(w.workspaceRoot, event.Name)
+	files, err := w.watchTree(dir)
 	if err != nil {
 		return
 	}
 
-	w.pendingFiles[relPath] = true
+	w.queue(files...)
+}
+
+// handleDirRemoved drops the watches of a removed (or renamed) directory.
+// The directory itself is queued so that the handler can drop its files.
+func (w *Watcher) handleDirRemoved(dir string) {
+	w.removeDir(dir)
+	w.queue(dir)
+}
+
+// queue schedules files & directories (as absolute or root-joined paths) for processing
+func (w *Watcher) queue(paths ...string) {
+	relPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		relPath, err := filepath.Rel(w.workspaceRoot, path)
+		if err != nil {
+			continue
+		}
+
+		relPaths = append(relPaths, relPath)
+	}
+
+	w.Enqueue(relPaths...)
+}
+
+// Enqueue schedules files (relative to the workspace root) for processing
+func (w *Watcher) Enqueue(filePaths ...string) {
+	if len(filePaths) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, filePath := range filePaths {
+		w.pendingFiles[filePath] = true
+	}
+
+	w.schedule()
+}
+
+// schedule (re)arms the debounce timer, must be called with mu held
+func (w *Watcher) schedule() {
+	if len(w.pendingFiles) == 0 {
+		return
+	}
+
+	now := time.Now()
+	if w.firstPendingAt.IsZero() {
+		w.firstPendingAt = now
+	}
 
 	if w.debounceTimer != nil {
 		w.debounceTimer.Stop()
 	}
 
-	w.debounceTimer = time.AfterFunc(w.debounceDuration, w.processPendingFiles)
+	w.debounceTimer = time.AfterFunc(w.delay(now), w.processPendingFiles)
 }
 
-func (w *Watcher) shouldIgnoreEvent(event fsnotify.Event) bool {
-	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
-		return true
+// delay is how long to wait for more changes before processing the pending ones,
+// must be called with mu held
+func (w *Watcher) delay(now time.Time) time.Duration {
+	delay := w.debounceDuration
+	if len(w.pendingFiles) >= bulkChangeThreshold {
+		delay = max(delay, bulkDebounceDuration)
 	}
 
-	return w.filter.ShouldIgnore(event.Name)
+	// Don't keep postponing indexing while changes keep streaming in
+	deadline := w.firstPendingAt.Add(maxDebounceWait)
+	return max(min(delay, deadline.Sub(now)), 0)
 }
 
-func (w *Watcher) processPendingFiles() {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-
+// takePending empties the pending set & returns its contents, must be called with mu held
+func (w *Watcher) takePending() []string {
 	changes := make([]string, 0, len(w.pendingFiles))
 	for filePath := range w.pendingFiles {
 		changes = append(changes, filePath)
 	}
 
+	w.pendingFiles = map[string]bool{}
+	w.firstPendingAt = time.Time{}
+	if w.debounceTimer != nil {
+		w.debounceTimer.Stop()
+	}
+
+	return changes
+}
+
+func (w *Watcher) processPendingFiles() {
+	w.mu.Lock()
+	changes := w.takePending()
+	w.mu.Unlock()
+
+	// Process outside the lock so that new events can keep queueing up
 	if len(changes) > 0 {
 		w.handler(w.ctx, changes)
 	}
-
-	w.pendingFiles = map[string]bool{}
 }
 
-func (w *Watcher) FlushPending() {
+// Forget drops files (relative to the workspace root) from the pending set,
+// e.g., because they're being processed synchronously
+func (w *Watcher) Forget(filePaths ...string) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if w.debounceTimer != nil {
-		w.debounceTimer.Reset(0)
+	for _, filePath := range filePaths {
+		delete(w.pendingFiles, filePath)
+	}
+
+	if len(w.pendingFiles) == 0 {
+		w.takePending()
 	}
 }
 
+// FlushPending synchronously processes all pending files
+func (w *Watcher) FlushPending() {
+	w.processPendingFiles()
+}
+
 func (w *Watcher) PendingCount() int {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -174,9 +445,11 @@ func (w *Watcher) PendingCount() int {
 func (w *Watcher) Close() error {
 	w.cancel()
 
+	w.mu.Lock()
 	if w.debounceTimer != nil {
 		w.debounceTimer.Stop()
 	}
+	w.mu.Unlock()
 
 	return w.fsWatcher.Close()
 }
@@ -0,0 +1,328 @@
+package fs
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for filePath, content := range files {
+		fullPath := filepath.Join(root, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+}
+
+// handledFiles collects the files a watcher hands over for processing
+type handledFiles struct {
+	mu    sync.Mutex
+	files map[string]bool
+}
+
+func (h *handledFiles) handle(ctx context.Context, filePaths []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, filePath := range filePaths {
+		h.files[filePath] = true
+	}
+}
+
+func (h *handledFiles) has(filePath string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.files[filePath]
+}
+
+// newTestWatcher watches root, handing changes over after a short debounce
+func newTestWatcher(t *testing.T, root string) (*Watcher, *handledFiles) {
+	t.Helper()
+
+	handled := &handledFiles{files: map[string]bool{}}
+	w, err := NewWatcher(context.Background(), root, []string{".go"}, handled.handle)
+	require.NoError(t, err)
+	t.Cleanup(func() { w.Close() })
+
+	w.mu.Lock()
+	w.debounceDuration = 10 * time.Millisecond
+	w.mu.Unlock()
+
+	require.NoError(t, w.ensureInitialized())
+	return w, handled
+}
+
+// newPollingWatcher is a watcher without OS watches, e.g., for driving rescans by hand
+func newPollingWatcher(t *testing.T, root string) *Watcher {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Watcher{
+		workspaceRoot:    root,
+		filter:           NewFileFilter(root, []string{".go"}),
+		handler:          func(ctx context.Context, filePaths []string) {},
+		pendingFiles:     map[string]bool{},
+		debounceDuration: time.Hour,
+		ctx:              ctx,
+		cancel:           cancel,
+		watchedDirs:      map[string]bool{},
+	}
+	t.Cleanup(func() {
+		cancel()
+		w.mu.Lock()
+		w.takePending()
+		w.mu.Unlock()
+	})
+
+	return w
+}
+
+func pendingFiles(w *Watcher) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	filePaths := []string{}
+	for filePath := range w.pendingFiles {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	return filePaths
+}
+
+func TestWatcherWatchesNewDirs(t *testing.T) {
+	root := t.TempDir()
+	w, handled := newTestWatcher(t, root)
+
+	writeFiles(t, root, map[string]string{"cart/cart.go": "package cart\n"})
+	cart := filepath.Join(root, "cart")
+
+	assert.Eventually(t, func() bool { return w.isWatchedDir(cart) }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return handled.has("cart/cart.go") }, 5*time.Second, 10*time.Millisecond)
+
+	// Changes within the new directory are picked up by its own watch
+	writeFiles(t, root, map[string]string{"cart/total.go": "package cart\n"})
+	assert.Eventually(t, func() bool { return handled.has("cart/total.go") }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWatcherDropsRemovedDirs(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"old/cart.go":      "package cart\n",
+		"old/nested/a.go":  "package nested\n",
+		"kept/checkout.go": "package kept\n",
+	})
+	w, handled := newTestWatcher(t, root)
+
+	old, renamed := filepath.Join(root, "old"), filepath.Join(root, "new")
+	require.True(t, w.isWatchedDir(filepath.Join(old, "nested")))
+
+	require.NoError(t, os.Rename(old, renamed))
+	assert.Eventually(t, func() bool {
+		return !w.isWatchedDir(old) && !w.isWatchedDir(filepath.Join(old, "nested")) &&
+			w.isWatchedDir(renamed) && w.isWatchedDir(filepath.Join(renamed, "nested"))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The old directory is handed over so that its files get dropped
+	assert.Eventually(t, func() bool {
+		return handled.has("old") && handled.has("new/cart.go") && handled.has("new/nested/a.go")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.RemoveAll(renamed))
+	assert.Eventually(t, func() bool {
+		return !w.isWatchedDir(renamed) && !w.isWatchedDir(filepath.Join(renamed, "nested"))
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, w.isWatchedDir(filepath.Join(root, "kept")))
+}
+
+func TestWatcherFallsBackToPolling(t *testing.T) {
+	for _, limit := range []error{syscall.ENOSPC, syscall.EMFILE} {
+		t.Run(limit.Error(), func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, map[string]string{"cart/cart.go": "package cart\n"})
+			w := newPollingWatcher(t, root)
+			w.addWatch = func(dir string) error { return limit }
+
+			require.NoError(t, w.addDir(filepath.Join(root, "cart")))
+
+			w.dirsMu.Lock()
+			assert.True(t, w.polling)
+			assert.Empty(t, w.watchedDirs)
+			w.dirsMu.Unlock()
+
+			// Polling scans the workspace right away
+			assert.Eventually(t, func() bool {
+				w.dirsMu.Lock()
+				defer w.dirsMu.Unlock()
+
+				_, scanned := w.snapshot[filepath.Join(root, "cart", "cart.go")]
+				return scanned
+			}, 5*time.Second, 10*time.Millisecond)
+
+			// Further directories aren't watched, the rescans cover them
+			w.addWatch = func(dir string) error {
+				t.Errorf("unexpected watch on %s", dir)
+				return nil
+			}
+			require.NoError(t, w.addDir(root))
+		})
+	}
+}
+
+func TestWatcherRescan(t *testing.T) {
+	root := t.TempDir()
+	cmd := exec.Command("git", "init", "-q")
+	cmd.Dir = root
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	writeFiles(t, root, map[string]string{
+		".gitignore":        "vendor/\ngenerated.go\n",
+		"cart.go":           "package cart\n",
+		"removed.go":        "package cart\n",
+		"unchanged.go":      "package cart\n",
+		"generated.go":      "package cart\n",
+		"notes.txt":         "not source\n",
+		"pkg/checkout.go":   "package pkg\n",
+		"vendor/dep/dep.go": "package dep\n",
+	})
+	w := newPollingWatcher(t, root)
+
+	w.snapshot = w.scan()
+	scanned := []string{}
+	for path := range w.snapshot {
+		rel, err := filepath.Rel(root, path)
+		require.NoError(t, err)
+		scanned = append(scanned, rel)
+	}
+	assert.ElementsMatch(t, []string{"cart.go", "removed.go", "unchanged.go", "pkg/checkout.go"}, scanned)
+
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "cart.go"), later, later))
+	require.NoError(t, os.Remove(filepath.Join(root, "removed.go")))
+	writeFiles(t, root, map[string]string{
+		"added.go":        "package cart\n",
+		"pkg/new/new.go":  "package new\n",
+		"vendor/new.go":   "package vendor\n",
+		"generated.go":    "package cart\n\nvar x int\n",
+		"pkg/ignored.txt": "not source\n",
+	})
+
+	w.rescan()
+	assert.Equal(t, []string{"added.go", "cart.go", "pkg/new/new.go", "removed.go"}, pendingFiles(w))
+
+	// Nothing changed since
+	w.mu.Lock()
+	w.takePending()
+	w.mu.Unlock()
+
+	w.rescan()
+	assert.Empty(t, pendingFiles(w))
+}
+
+func TestWatcherDelay(t *testing.T) {
+	w := newPollingWatcher(t, t.TempDir())
+	w.debounceDuration = debounceDuration
+	start := time.Now()
+
+	tests := []struct {
+		name     string
+		nFiles   int
+		elapsed  time.Duration
+		expected time.Duration
+	}{
+		{name: "small batch", nFiles: 1, expected: debounceDuration},
+		{name: "just below the bulk threshold", nFiles: bulkChangeThreshold - 1, expected: debounceDuration},
+		{name: "bulk change", nFiles: bulkChangeThreshold, expected: bulkDebounceDuration},
+		{name: "near the max wait", nFiles: bulkChangeThreshold, elapsed: maxDebounceWait - 5*time.Second, expected: 5 * time.Second},
+		{name: "past the max wait", nFiles: 1, elapsed: maxDebounceWait + time.Second, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+
+			w.pendingFiles = map[string]bool{}
+			for i := range tt.nFiles {
+				w.pendingFiles[filepath.Join("pkg", string(rune('a'+i))+".go")] = true
+			}
+			w.firstPendingAt = start
+
+			assert.Equal(t, tt.expected, w.delay(start.Add(tt.elapsed)))
+		})
+	}
+}
+
+func TestWatcherPending(t *testing.T) {
+	w := newPollingWatcher(t, t.TempDir())
+	var handled [][]string
+	w.handler = func(ctx context.Context, filePaths []string) {
+		sort.Strings(filePaths)
+		handled = append(handled, filePaths)
+	}
+
+	// Nothing to schedule
+	w.Enqueue()
+	w.mu.Lock()
+	assert.Nil(t, w.debounceTimer)
+	w.mu.Unlock()
+
+	w.Enqueue("cart.go", "checkout.go")
+	w.Enqueue("cart.go", "pricing.go")
+	assert.Equal(t, []string{"cart.go", "checkout.go", "pricing.go"}, pendingFiles(w))
+
+	w.mu.Lock()
+	firstPendingAt := w.firstPendingAt
+	assert.False(t, firstPendingAt.IsZero())
+	assert.NotNil(t, w.debounceTimer)
+	w.mu.Unlock()
+
+	// Later changes don't push back the max wait
+	w.Enqueue("orders.go")
+	w.mu.Lock()
+	assert.Equal(t, firstPendingAt, w.firstPendingAt)
+	w.mu.Unlock()
+
+	w.Forget("checkout.go", "unknown.go")
+	assert.Equal(t, []string{"cart.go", "orders.go", "pricing.go"}, pendingFiles(w))
+
+	w.FlushPending()
+	assert.Equal(t, [][]string{{"cart.go", "orders.go", "pricing.go"}}, handled)
+	assert.Equal(t, 0, w.PendingCount())
+	w.mu.Lock()
+	assert.True(t, w.firstPendingAt.IsZero())
+	w.mu.Unlock()
+
+	// Flushing an empty set doesn't call the handler
+	w.FlushPending()
+	assert.Len(t, handled, 1)
+
+	// Forgetting everything resets the max wait
+	w.Enqueue("cart.go")
+	w.Forget("cart.go")
+	w.mu.Lock()
+	assert.True(t, w.firstPendingAt.IsZero())
+	w.mu.Unlock()
+}
+
+func TestWatcherDebounces(t *testing.T) {
+	root := t.TempDir()
+	w, handled := newTestWatcher(t, root)
+
+	w.Enqueue("cart.go")
+	assert.Eventually(t, func() bool { return handled.has("cart.go") }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, w.PendingCount())
+}
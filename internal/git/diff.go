@@ -0,0 +1,201 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FileStatus describes how a file changed in a diff
+type FileStatus string
+
+const (
+	FileAdded    FileStatus = "added"
+	FileDeleted  FileStatus = "deleted"
+	FileModified FileStatus = "modified"
+)
+
+// Hunk is a changed region of a file as line ranges in the old & new versions.
+// A zero line count means lines were only added (old) or only removed (new)
+// right after the start line.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+}
+
+// TouchesNew reports whether the hunk changes any of the new version's lines in [start, end]
+func (h Hunk) TouchesNew(start, end int) bool {
+	return touches(h.NewStart, h.NewLines, start, end)
+}
+
+func touches(hunkStart, hunkLines, start, end int) bool {
+	if hunkLines == 0 {
+		// Lines were inserted/removed between hunkStart & hunkStart+1
+		return start <= hunkStart && hunkStart+1 <= end
+	}
+
+	return hunkStart <= end && start <= hunkStart+hunkLines-1
+}
+
+// FileDiff describes the changes made to a single file
+type FileDiff struct {
+	Path    string // path in the new version, relative to dir
+	OldPath string // path in the old version, relative to dir
+	Status  FileStatus
+	Hunks   []Hunk
+}
+
+// Diff returns the changes between rev & the working tree (relative to dir).
+// Renames are reported as a deletion plus an addition.
+func Diff(dir, rev string) ([]FileDiff, error) {
+	err := verifyCommit(dir, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := run(
+		dir,
+		"diff", "--unified=0", "--relative", "--no-renames",
+		"--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/",
+		"--end-of-options", rev,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []FileDiff
+	var current *FileDiff
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			diffs = append(diffs, FileDiff{Status: FileModified})
+			current = &diffs[len(diffs)-1]
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "--- "):
+			current.OldPath = parseDiffPath(line[4:], "a/")
+			if current.OldPath == "" {
+				current.Status = FileAdded
+			}
+		case strings.HasPrefix(line, "+++ "):
+			current.Path = parseDiffPath(line[4:], "b/")
+			if current.Path == "" {
+				current.Status = FileDeleted
+				current.Path = current.OldPath
+			}
+		case strings.HasPrefix(line, "@@ "):
+			hunk, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+
+			current.Hunks = append(current.Hunks, hunk)
+		}
+	}
+
+	// Binary files & mode changes don't have paths
+	valid := diffs[:0]
+	for _, diff := range diffs {
+		if diff.Path != "" {
+			valid = append(valid, diff)
+		}
+	}
+
+	return valid, scanner.Err()
+}
+
+// UncommittedDiff returns the changes between HEAD & the working tree (relative to dir),
+// including untracked files that aren't ignored
+func UncommittedDiff(dir string) ([]FileDiff, error) {
+	diffs, err := Diff(dir, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := run(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, filePath := range splitLines(untracked) {
+		diffs = append(diffs, FileDiff{Path: filePath, Status: FileAdded})
+	}
+
+	return diffs, nil
+}
+
+// verifyCommit checks that rev names a commit, e.g., a branch, tag, or hash.
+// Revs are user input, so ones that git would parse as options are rejected.
+func verifyCommit(dir, rev string) error {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return fmt.Errorf("invalid revision: %q", rev)
+	}
+
+	_, err := run(dir, "rev-parse", "--verify", "--quiet", "--end-of-options", rev+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("unknown revision: %q", rev)
+	}
+
+	return nil
+}
+
+func parseDiffPath(path, prefix string) string {
+	if path == "/dev/null" {
+		return ""
+	}
+
+	path = strings.TrimSuffix(path, "\t")
+	if unquoted, err := strconv.Unquote(path); err == nil {
+		path = unquoted
+	}
+
+	return strings.TrimPrefix(path, prefix)
+}
+
+// parseHunkHeader parses headers like "@@ -10,2 +10,3 @@ func foo() {"
+func parseHunkHeader(line string) (Hunk, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return Hunk{}, fmt.Errorf("invalid hunk header: %s", line)
+	}
+
+	oldStart, oldLines, err := parseRange(strings.TrimPrefix(fields[1], "-"))
+	if err != nil {
+		return Hunk{}, fmt.Errorf("invalid hunk header: %s", line)
+	}
+
+	newStart, newLines, err := parseRange(strings.TrimPrefix(fields[2], "+"))
+	if err != nil {
+		return Hunk{}, fmt.Errorf("invalid hunk header: %s", line)
+	}
+
+	return Hunk{
+		OldStart: oldStart, OldLines: oldLines,
+		NewStart: newStart, NewLines: newLines,
+	}, nil
+}
+
+// parseRange parses ranges like "10,2" or "10" (a single line)
+func parseRange(r string) (int, int, error) {
+	startStr, linesStr, found := strings.Cut(r, ",")
+
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !found {
+		return start, 1, nil
+	}
+
+	lines, err := strconv.Atoi(linesStr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return start, lines, nil
+}
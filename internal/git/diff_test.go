@@ -0,0 +1,153 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	return dir
+}
+
+func TestDiffRevisions(t *testing.T) {
+	dir := newTestRepo(t)
+	output := filepath.Join(t.TempDir(), "x")
+
+	tests := []struct {
+		name string
+		rev  string
+		err  string
+	}{
+		{name: "commit", rev: "HEAD"},
+		{name: "option", rev: "--output=" + output, err: "invalid revision"},
+		{name: "empty", rev: "", err: "invalid revision"},
+		{name: "unknown", rev: "no-such-branch", err: "unknown revision"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Diff(dir, tt.rev)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+
+	_, err := os.Stat(output)
+	assert.True(t, os.IsNotExist(err), "git wrote %s", output)
+}
+
+func TestParseHunkHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		hunk   Hunk
+	}{
+		{name: "modification", header: "@@ -10,2 +10,3 @@ func foo() {", hunk: Hunk{10, 2, 10, 3}},
+		{name: "single line", header: "@@ -5 +5 @@", hunk: Hunk{5, 1, 5, 1}},
+		{name: "pure addition", header: "@@ -3,0 +4,2 @@", hunk: Hunk{3, 0, 4, 2}},
+		{name: "pure deletion", header: "@@ -4,2 +3,0 @@", hunk: Hunk{4, 2, 3, 0}},
+		{name: "added file", header: "@@ -0,0 +1,3 @@", hunk: Hunk{0, 0, 1, 3}},
+		{name: "deleted file", header: "@@ -1,3 +0,0 @@", hunk: Hunk{1, 3, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hunk, err := parseHunkHeader(tt.header)
+			require.NoError(t, err)
+			assert.Equal(t, tt.hunk, hunk)
+		})
+	}
+
+	_, err := parseHunkHeader("@@ -a +1 @@")
+	assert.Error(t, err)
+}
+
+func TestTouchesNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		hunk       Hunk
+		start, end int
+		touches    bool
+	}{
+		{name: "overlapping", hunk: Hunk{NewStart: 10, NewLines: 3}, start: 12, end: 20, touches: true},
+		{name: "before", hunk: Hunk{NewStart: 10, NewLines: 3}, start: 13, end: 20, touches: false},
+		{name: "after", hunk: Hunk{NewStart: 10, NewLines: 3}, start: 1, end: 9, touches: false},
+		{name: "addition within", hunk: Hunk{OldLines: 0, NewStart: 5, NewLines: 2}, start: 4, end: 8, touches: true},
+		// Deleted lines were between new lines 3 & 4
+		{name: "deletion within", hunk: Hunk{OldStart: 4, OldLines: 2, NewStart: 3}, start: 1, end: 10, touches: true},
+		{name: "deletion at the end", hunk: Hunk{OldStart: 4, OldLines: 2, NewStart: 3}, start: 1, end: 3, touches: false},
+		{name: "deletion at the start", hunk: Hunk{OldStart: 4, OldLines: 2, NewStart: 3}, start: 4, end: 10, touches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.touches, tt.hunk.TouchesNew(tt.start, tt.end))
+		})
+	}
+}
+
+func TestDiffFileStatuses(t *testing.T) {
+	dir := newTestRepo(t)
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	write("kept.go", "a\nb\nc\n")
+	write("removed.go", "x\ny\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "base")
+
+	write("kept.go", "a\nnew\nb\n")
+	write("added.go", "1\n2\n3\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "removed.go")))
+	git("add", "-A")
+
+	diffs, err := Diff(dir, "HEAD")
+	require.NoError(t, err)
+
+	byPath := map[string]FileDiff{}
+	for _, diff := range diffs {
+		byPath[diff.Path] = diff
+	}
+
+	assert.Equal(t, FileDiff{
+		Path: "added.go", Status: FileAdded,
+		Hunks: []Hunk{{0, 0, 1, 3}},
+	}, byPath["added.go"])
+	assert.Equal(t, FileDiff{
+		Path: "removed.go", OldPath: "removed.go", Status: FileDeleted,
+		Hunks: []Hunk{{1, 2, 0, 0}},
+	}, byPath["removed.go"])
+	assert.Equal(t, FileDiff{
+		Path: "kept.go", OldPath: "kept.go", Status: FileModified,
+		// A pure addition after line 1 & a pure deletion of line 3
+		Hunks: []Hunk{{1, 0, 2, 1}, {3, 1, 3, 0}},
+	}, byPath["kept.go"])
+}
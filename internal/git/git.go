@@ -0,0 +1,140 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Head describes the commit checked out in a repository
+type Head struct {
+	Commit string
+	Branch string // empty when HEAD is detached
+}
+
+// GetHead returns the commit & branch currently checked out in dir's repository
+func GetHead(dir string) (*Head, error) {
+	commit, err := run(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	// Fails when HEAD is detached
+	branch, _ := run(dir, "symbolic-ref", "--short", "-q", "HEAD")
+
+	return &Head{Commit: commit, Branch: branch}, nil
+}
+
+// UncommittedFiles returns the paths (relative to dir) of files that differ
+// from HEAD, including untracked files that aren't ignored
+func UncommittedFiles(dir string) ([]string, error) {
+	modified, err := run(dir, "diff", "--name-only", "--relative", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := run(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(splitLines(modified), splitLines(untracked)...), nil
+}
+
+// Commit describes a commit that touched a specific file
+type Commit struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Message string
+	Path    string // the file's path (relative to dir) as of this commit
+}
+
+// FileLog returns up to limit commits that touched filePath (relative to dir),
+// newest first, following renames
+func FileLog(dir, filePath string, limit int) ([]Commit, error) {
+	out, err := run(
+		dir,
+		"log", "--follow", "--relative", "--name-only",
+		fmt.Sprintf("--max-count=%d", limit),
+		"--format=%x1e%H%x1f%an%x1f%aI%x1f%B%x1d",
+		"--", filePath,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(record, "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+
+		message, names, _ := strings.Cut(fields[3], "\x1d")
+		date, _ := time.Parse(time.RFC3339, fields[2])
+
+		commit := Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Message: strings.TrimSpace(message),
+			Path:    filePath,
+		}
+
+		for _, name := range splitLines(strings.TrimSpace(names)) {
+			if name != "" {
+				commit.Path = name
+				break
+			}
+		}
+
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
+// Show returns the contents of filePath (relative to dir) as of rev
+func Show(dir, rev, filePath string) ([]byte, error) {
+	return runRaw(dir, "show", rev+":./"+filePath)
+}
+
+func run(dir string, args ...string) (string, error) {
+	out, err := runRaw(dir, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+func runRaw(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+
+	return out, nil
+}
+
+func splitLines(out string) []string {
+	if out == "" {
+		return nil
+	}
+
+	return strings.Split(out, "\n")
+}
+
+// ShortHash abbreviates a commit hash for display
+func ShortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+
+	return hash
+}
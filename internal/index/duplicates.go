@@ -0,0 +1,316 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/philippgille/chromem-go"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+const (
+	DefaultDuplicateThreshold = 0.95
+	DefaultDuplicateMinTokens = 32
+)
+
+// DuplicateOptions configures duplicate detection, zero values fall back to defaults
+type DuplicateOptions struct {
+	Threshold float32 // minimum similarity of near-duplicates
+	MinTokens int     // ignore chunks smaller than this, e.g., trivial getters
+	Dir       string  // only consider chunks in this directory
+	// MaxCandidates bounds how many chunks are compared pairwise for near-duplicates, unlimited if <= 0
+	MaxCandidates int
+	// Exact only reports chunks whose code is identical after dropping comments & whitespace
+	// and ignoring their own names, e.g., copy-pasted functions that were renamed
+	Exact bool
+}
+
+// DuplicateCluster is a group of chunks that duplicate each other
+type DuplicateCluster struct {
+	Chunks     []*parser.Chunk
+	Similarity float32 // lowest similarity linking the cluster, 1 for exact duplicates
+	Exact      bool    // identical code after normalization
+}
+
+// Tokens estimates how many tokens deduplicating the cluster would save
+func (c *DuplicateCluster) Tokens() int {
+	var total, largest int
+	for _, chunk := range c.Chunks {
+		tokens := chunk.Tokens()
+		total += tokens
+		largest = max(largest, tokens)
+	}
+
+	return total - largest
+}
+
+// FormatDuplicates renders duplicate clusters, one chunk per line under each cluster's header
+func FormatDuplicates(clusters []DuplicateCluster) string {
+	var b strings.Builder
+	for i, cluster := range clusters {
+		kind := fmt.Sprintf("similarity >= %.2f", cluster.Similarity)
+		if cluster.Exact {
+			kind = "exact"
+		}
+
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(
+			&b, "Cluster %d: %d chunks, ~%d duplicated tokens (%s)\n",
+			i+1, len(cluster.Chunks), cluster.Tokens(), kind,
+		)
+		for _, chunk := range cluster.Chunks {
+			fmt.Fprintf(&b, "- %s\n", FormatChunk(chunk))
+		}
+	}
+
+	return b.String()
+}
+
+type duplicateCandidate struct {
+	chunk     *parser.Chunk
+	embedding []float32
+	hash      uint64
+}
+
+// FindDuplicates clusters named chunks that are near-duplicates of each other, i.e., whose
+// embeddings are at least opts.Threshold similar, or exact duplicates if opts.Exact is set.
+// Clusters are connected components, so members are linked through chains of similar chunks.
+// Clusters with the most duplicated code come first.
+func (idx *Index) FindDuplicates(ctx context.Context, opts DuplicateOptions) ([]DuplicateCluster, error) {
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultDuplicateThreshold
+	}
+
+	if opts.MinTokens <= 0 {
+		opts.MinTokens = DefaultDuplicateMinTokens
+	}
+
+	docs, err := idx.collection.ListDocumentsShallow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := duplicateCandidates(docs, opts)
+	if !opts.Exact && opts.MaxCandidates > 0 && len(candidates) > opts.MaxCandidates {
+		return nil, fmt.Errorf(
+			"too many chunks to compare (%d, max %d), narrow down with dir or min_tokens",
+			len(candidates), opts.MaxCandidates,
+		)
+	}
+
+	components := newUnionFind(len(candidates))
+	linkSimilarity := make([]float32, len(candidates)) // lowest similarity linking each root's cluster
+	for i := range linkSimilarity {
+		linkSimilarity[i] = 1
+	}
+
+	var mu sync.Mutex
+	link := func(i, j int, similarity float32) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		rootI, rootJ := components.find(i), components.find(j)
+		lowest := min(linkSimilarity[rootI], linkSimilarity[rootJ], similarity)
+		linkSimilarity[components.union(rootI, rootJ)] = lowest
+	}
+
+	if opts.Exact {
+		byHash := map[uint64]int{}
+		for i, candidate := range candidates {
+			first, exists := byHash[candidate.hash]
+			if exists {
+				link(first, i, 1)
+			} else {
+				byHash[candidate.hash] = i
+			}
+		}
+	} else {
+		// Compare all pairs, embeddings are normalized so their dot product is their cosine similarity
+		var wg sync.WaitGroup
+		rows := make(chan int)
+		for range runtime.NumCPU() {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range rows {
+					// Drain the remaining rows once cancelled
+					if ctx.Err() != nil {
+						continue
+					}
+
+					for j := i + 1; j < len(candidates); j++ {
+						if encloses(candidates[i].chunk, candidates[j].chunk) {
+							continue
+						}
+
+						similarity := dot(candidates[i].embedding, candidates[j].embedding)
+						if similarity >= opts.Threshold {
+							link(i, j, similarity)
+						}
+					}
+				}
+			}()
+		}
+
+		for i := range candidates {
+			rows <- i
+		}
+		close(rows)
+		wg.Wait()
+
+		err = ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	members := map[int][]int{}
+	for i := range candidates {
+		root := components.find(i)
+		members[root] = append(members[root], i)
+	}
+
+	var clusters []DuplicateCluster
+	for root, indices := range members {
+		if len(indices) < 2 {
+			continue
+		}
+
+		cluster := DuplicateCluster{Similarity: linkSimilarity[root], Exact: true}
+		for _, i := range indices {
+			cluster.Chunks = append(cluster.Chunks, candidates[i].chunk)
+			cluster.Exact = cluster.Exact && candidates[i].hash == candidates[indices[0]].hash
+		}
+
+		sort.Slice(cluster.Chunks, func(i, j int) bool {
+			return cluster.Chunks[i].ID() < cluster.Chunks[j].ID()
+		})
+
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Tokens() != clusters[j].Tokens() {
+			return clusters[i].Tokens() > clusters[j].Tokens()
+		}
+
+		return clusters[i].Chunks[0].ID() < clusters[j].Chunks[0].ID()
+	})
+
+	return clusters, nil
+}
+
+// duplicateCandidates selects the named chunks in scope that are large enough to matter.
+// Split chunks are compared as a whole rather than through their parts.
+func duplicateCandidates(docs []*chromem.Document, opts DuplicateOptions) []duplicateCandidate {
+	dir := strings.Trim(path.Clean("/"+opts.Dir), "/")
+
+	var candidates []duplicateCandidate
+	for _, doc := range docs {
+		if doc.Metadata["format"] != indexFormat || doc.Metadata["parent"] != "" || doc.Metadata["name"] == "" {
+			continue
+		}
+
+		if dir != "" && !strings.HasPrefix(doc.Metadata["file"], dir+"/") {
+			continue
+		}
+
+		chunk := chunkFromDoc(doc)
+		if chunk.Tokens() < opts.MinTokens {
+			continue
+		}
+
+		hash, err := strconv.ParseUint(doc.Metadata["codeHash"], 16, 64)
+		if err != nil {
+			continue
+		}
+
+		candidates = append(candidates, duplicateCandidate{
+			chunk:     chunk,
+			embedding: doc.Embedding,
+			hash:      hash,
+		})
+	}
+
+	// Keep clusters stable across runs
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].chunk.ID() < candidates[j].chunk.ID()
+	})
+
+	return candidates
+}
+
+var codeToken = regexp.MustCompile(`[\p{L}_][\p{L}\p{N}_]*|\p{N}+|\S`)
+
+// normalizeCode reduces code, i.e., source without comments, to its tokens with its own name
+// masked, so that renamed copies & reformatted code normalize to the same thing
+func normalizeCode(code, name string) string {
+	tokens := codeToken.FindAllString(code, -1)
+	for i, token := range tokens {
+		if token == name {
+			tokens[i] = "_"
+		}
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// encloses reports whether one chunk contains the other, e.g., a class & its methods
+func encloses(a, b *parser.Chunk) bool {
+	if a.File != b.File {
+		return false
+	}
+
+	return strings.HasPrefix(a.Path, b.Path+"::") || strings.HasPrefix(b.Path, a.Path+"::")
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range min(len(a), len(b)) {
+		sum += a[i] * b[i]
+	}
+
+	return sum
+}
+
+type unionFind []int
+
+func newUnionFind(n int) unionFind {
+	parents := make(unionFind, n)
+	for i := range parents {
+		parents[i] = i
+	}
+
+	return parents
+}
+
+func (u unionFind) find(i int) int {
+	for u[i] != i {
+		u[i] = u[u[i]]
+		i = u[i]
+	}
+
+	return i
+}
+
+// union merges the sets of i & j, returning the new root
+func (u unionFind) union(i, j int) int {
+	rootI, rootJ := u.find(i), u.find(j)
+	u[rootJ] = rootI
+	return rootI
+}
@@ -0,0 +1,213 @@
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const duplicatesSource = `package shop
+
+// Total sums the prices of the items in the cart, applying the discount
+func Total(items []Item, discount float64) float64 {
+	sum := 0.0
+	for _, item := range items {
+		sum += item.Price * float64(item.Quantity)
+	}
+
+	return sum * (1 - discount)
+}
+
+// CartTotal sums the prices of the items in the cart, applying the discount
+func CartTotal(items []Item, discount float64) float64 {
+	sum := 0.0
+	for _, item := range items {
+		sum += item.Price *   float64(item.Quantity)
+	}
+	return sum * (1 - discount)
+}
+
+// SubTotal sums the prices of the items in the cart, applying the discount
+func SubTotal(items []Item, discount float64) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.Price * float64(item.Quantity)
+	}
+
+	return total * (1 - discount)
+}
+
+// Shipping estimates what delivery costs by weight
+func Shipping(items []Item, rate float64) float64 {
+	weight := 0.0
+	for _, item := range items {
+		weight += item.Weight * float64(item.Quantity)
+	}
+
+	return weight * rate
+}
+`
+
+func newTestIndex(t *testing.T, files map[string]string) *Index {
+	t.Helper()
+
+	root := t.TempDir()
+	p, err := parser.NewGoParser(root)
+	require.NoError(t, err)
+	defer p.Close()
+
+	ctx := context.Background()
+	idx, err := New(ctx, root, Options{
+		DBPath:   filepath.Join(t.TempDir(), "db"),
+		Embedder: NewHashingEmbedder(DefaultHashingDimensions),
+	})
+	require.NoError(t, err)
+
+	for filePath, source := range files {
+		fullPath := filepath.Join(root, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(source), 0o644))
+
+		file, err := p.Chunk(filePath)
+		require.NoError(t, err)
+		require.NoError(t, idx.Index(ctx, file))
+	}
+
+	return idx
+}
+
+func clusterIDs(clusters []DuplicateCluster) [][]string {
+	ids := [][]string{}
+	for _, cluster := range clusters {
+		var clusterIDs []string
+		for _, chunk := range cluster.Chunks {
+			clusterIDs = append(clusterIDs, chunk.ID())
+		}
+		ids = append(ids, clusterIDs)
+	}
+
+	return ids
+}
+
+func TestFindDuplicates(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"shop/cart.go":  duplicatesSource,
+		"other/cart.go": duplicatesSource,
+	})
+
+	tests := []struct {
+		name     string
+		opts     DuplicateOptions
+		expected [][]string
+	}{
+		{
+			name: "Exact",
+			opts: DuplicateOptions{Exact: true, MinTokens: 1},
+			expected: [][]string{
+				{"other/cart.go::CartTotal", "other/cart.go::Total", "shop/cart.go::CartTotal", "shop/cart.go::Total"},
+				{"other/cart.go::SubTotal", "shop/cart.go::SubTotal"},
+				{"other/cart.go::Shipping", "shop/cart.go::Shipping"},
+			},
+		},
+		{
+			name: "Scoped To Directory",
+			opts: DuplicateOptions{Exact: true, MinTokens: 1, Dir: "shop"},
+			expected: [][]string{
+				{"shop/cart.go::CartTotal", "shop/cart.go::Total"},
+			},
+		},
+		{
+			name:     "Small Chunks Ignored",
+			opts:     DuplicateOptions{Exact: true, MinTokens: 1000},
+			expected: [][]string{},
+		},
+		{
+			name: "Near Duplicates",
+			opts: DuplicateOptions{Threshold: 0.85, MinTokens: 1, Dir: "shop"},
+			expected: [][]string{
+				{"shop/cart.go::CartTotal", "shop/cart.go::SubTotal", "shop/cart.go::Total"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusters, err := idx.FindDuplicates(context.Background(), tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, clusterIDs(clusters))
+
+			for _, cluster := range clusters {
+				assert.GreaterOrEqual(t, cluster.Similarity, tt.opts.Threshold)
+			}
+		})
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	a := normalizeCode("func Total(x int) int {\n\treturn x  + 1\n}", "Total")
+	b := normalizeCode("func Sum(x int) int { return x+1 }", "Sum")
+	c := normalizeCode("func Sum(x int) int { return x+2 }", "Sum")
+
+	assert.Equal(t, "func _ ( x int ) int { return x + 1 }", a)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestFindExactDuplicatesIgnoresComments(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"store/set.go": `package store
+
+// Set stores v at p
+func Set(p *int, v int) {
+	*p = v // the value
+	*p += 1
+	*p *= 2
+	/* done */
+}
+`,
+		"store/put.go": `package store
+
+func Put(p *int, v int) {
+	*p = v
+	*p += 1 /* once */
+	*p *= 2
+}
+`,
+		"store/reset.go": `package store
+
+func Reset(p *int, v int) {
+	*p = v
+	*p += 1
+	*p *= 3
+}
+`,
+	})
+
+	clusters, err := idx.FindDuplicates(context.Background(), DuplicateOptions{Exact: true, MinTokens: 1})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"store/put.go::Put", "store/set.go::Set"}}, clusterIDs(clusters))
+}
+
+func TestFindDuplicatesBounds(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"shop/cart.go":  duplicatesSource,
+		"other/cart.go": duplicatesSource,
+	})
+
+	_, err := idx.FindDuplicates(context.Background(), DuplicateOptions{MinTokens: 1, MaxCandidates: 4})
+	assert.ErrorContains(t, err, "too many chunks to compare")
+
+	// Exact duplicates are found by hash rather than compared pairwise
+	_, err = idx.FindDuplicates(context.Background(), DuplicateOptions{Exact: true, MinTokens: 1, MaxCandidates: 4})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = idx.FindDuplicates(ctx, DuplicateOptions{MinTokens: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+}
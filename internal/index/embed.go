@@ -0,0 +1,100 @@
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cespare/xxhash"
+	"github.com/philippgille/chromem-go"
+)
+
+// cachedEmbeddingFunc wraps embed so that vectors are keyed by content hash.
+// Content that was embedded before, e.g., before switching branches back & forth,
+// is served from the cache instead of being embedded again.
+func cachedEmbeddingFunc(embed chromem.EmbeddingFunc, cache *chromem.Collection) chromem.EmbeddingFunc {
+	return func(ctx context.Context, text string) ([]float32, error) {
+		hash := hashContent([]byte(text))
+
+		doc, err := cache.GetByID(ctx, hash)
+		if err == nil {
+			return doc.Embedding, nil
+		}
+
+		embedding, err := embed(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+
+		err = cache.AddDocument(ctx, chromem.Document{ID: hash, Embedding: embedding})
+		if err != nil {
+			return nil, fmt.Errorf("failed to cache embedding: %w", err)
+		}
+
+		return embedding, nil
+	}
+}
+
+func hashContent(content []byte) string {
+	return fmt.Sprintf("%x", xxhash.Sum64(content))
+}
+
+// EmbeddingHashes returns the content hashes of what was embedded for the indexed chunks,
+// i.e., the keys of their cached embeddings
+func (idx *Index) EmbeddingHashes(ctx context.Context) ([]string, error) {
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := idx.collection.ListDocumentsPartial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(docs))
+	hashes := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		hash := hashContent([]byte(doc.Content))
+		if !seen[hash] {
+			seen[hash] = true
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes, nil
+}
+
+// PruneEmbeddings evicts cached embeddings that neither the indexed chunks nor keep use,
+// e.g., ones only used by commits that are no longer checked out, returning how many were evicted
+func (idx *Index) PruneEmbeddings(ctx context.Context, keep map[string]bool) (int, error) {
+	hashes, err := idx.EmbeddingHashes(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	used := make(map[string]bool, len(hashes)+len(keep))
+	for _, hash := range hashes {
+		used[hash] = true
+	}
+	for hash := range keep {
+		used[hash] = true
+	}
+
+	var unused []string
+	for _, hash := range idx.embeddings.ListIDs(ctx) {
+		if !used[hash] {
+			unused = append(unused, hash)
+		}
+	}
+
+	if len(unused) == 0 {
+		return 0, nil
+	}
+
+	err = idx.embeddings.Delete(ctx, nil, nil, unused...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune embeddings cache: %w", err)
+	}
+
+	return len(unused), nil
+}
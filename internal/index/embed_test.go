@@ -0,0 +1,39 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/philippgille/chromem-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneEmbeddings(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"cart.go": "package cart\n\nfunc Total() int { return 0 }\n",
+	})
+	ctx := context.Background()
+
+	hashes, err := idx.EmbeddingHashes(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, hashes)
+
+	// e.g., embeddings of chunks from other branches
+	embedding := make([]float32, DefaultHashingDimensions)
+	embedding[0] = 1
+	for _, hash := range []string{"kept", "stale"} {
+		require.NoError(t, idx.embeddings.AddDocument(ctx, chromem.Document{ID: hash, Embedding: embedding}))
+	}
+
+	evicted, err := idx.PruneEmbeddings(ctx, map[string]bool{"kept": true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, evicted)
+
+	cached := idx.embeddings.ListIDs(ctx)
+	assert.Contains(t, cached, "kept")
+	assert.NotContains(t, cached, "stale")
+	for _, hash := range hashes {
+		assert.Contains(t, cached, hash)
+	}
+}
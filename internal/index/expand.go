@@ -0,0 +1,119 @@
+package index
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// stopWords are ignored when relating queries to symbols
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "by": true,
+	"code": true, "do": true, "does": true, "for": true, "from": true, "get": true, "how": true,
+	"in": true, "is": true, "it": true, "of": true, "on": true, "or": true, "that": true,
+	"the": true, "this": true, "to": true, "what": true, "when": true, "where": true,
+	"which": true, "with": true,
+}
+
+// ExpandQuery returns identifiers from the workspace's symbol vocabulary that relate to the query,
+// e.g., "rate limiting" -> RateLimiter by name, or throttle through its doc comment.
+// Identifiers matching by name rank before those matching through their summaries.
+func (idx *Index) ExpandQuery(ctx context.Context, query string, limit int) ([]string, error) {
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryStems := stemWords(query)
+	if len(queryStems) == 0 {
+		return nil, nil
+	}
+
+	mentioned := map[string]bool{}
+	for _, term := range strings.Fields(query) {
+		mentioned[strings.ToLower(term)] = true
+	}
+
+	type candidate struct {
+		name  string
+		score float64
+	}
+
+	scores := map[string]float64{}
+	idx.cacheMu.RLock()
+	for _, symbols := range idx.symbols {
+		for _, symbol := range symbols {
+			if mentioned[strings.ToLower(symbol.Name)] {
+				continue
+			}
+
+			score := relatedness(queryStems, stemWords(symbol.Name), stemWords(symbol.Summary))
+			if score > scores[symbol.Name] {
+				scores[symbol.Name] = score
+			}
+		}
+	}
+	idx.cacheMu.RUnlock()
+
+	candidates := make([]candidate, 0, len(scores))
+	for name, score := range scores {
+		if score > 0 {
+			candidates = append(candidates, candidate{name, score})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+
+		if len(candidates[i].name) != len(candidates[j].name) {
+			return len(candidates[i].name) < len(candidates[j].name)
+		}
+
+		return candidates[i].name < candidates[j].name
+	})
+
+	expansions := make([]string, 0, min(limit, len(candidates)))
+	for _, candidate := range candidates[:min(limit, len(candidates))] {
+		expansions = append(expansions, candidate.name)
+	}
+
+	return expansions, nil
+}
+
+// relatedness scores how well a symbol covers the query: name hits count double
+// & summary hits only count if they cover most of the query
+func relatedness(query, name, summary map[string]bool) float64 {
+	var nameHits, summaryHits int
+	for stem := range query {
+		if name[stem] {
+			nameHits++
+		} else if summary[stem] {
+			summaryHits++
+		}
+	}
+
+	coverage := float64(nameHits+summaryHits) / float64(len(query))
+	if nameHits == 0 && coverage < 0.5 {
+		return 0
+	}
+
+	// Prefer names that are mostly made up of query words, e.g., RateLimiter over RateLimiterMetricsExporter
+	precision := float64(nameHits) / float64(max(len(name), 1))
+	return 2*float64(nameHits) + float64(summaryHits) + precision
+}
+
+// stemWords splits text & identifiers into the stems of their words, ignoring stop words
+func stemWords(text string) map[string]bool {
+	stems := map[string]bool{}
+	for _, word := range parser.SplitIdentifier(text) {
+		if len(word) > 1 && !stopWords[word] {
+			stems[parser.Stem(word)] = true
+		}
+	}
+
+	return stems
+}
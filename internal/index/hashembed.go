@@ -0,0 +1,80 @@
+package index
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/cespare/xxhash"
+	"github.com/philippgille/chromem-go"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// DefaultHashingDimensions is the vector size of the hashing embedder
+const DefaultHashingDimensions = 512
+
+// NewHashingEmbedder returns an offline & deterministic embedding function that hashes
+// the words of a text into a fixed number of dimensions. It's a lexical stand-in for
+// a real embedding model, e.g., so that evaluations can run in CI.
+func NewHashingEmbedder(dimensions int) chromem.EmbeddingFunc {
+	if dimensions <= 0 {
+		dimensions = DefaultHashingDimensions
+	}
+
+	return func(ctx context.Context, text string) ([]float32, error) {
+		vector := make([]float32, dimensions)
+		for _, word := range hashingWords(text) {
+			hash := xxhash.Sum64String(word)
+
+			// The sign bit reduces the bias from collisions
+			sign := float32(1)
+			if hash>>63 == 1 {
+				sign = -1
+			}
+
+			vector[hash%uint64(dimensions)] += sign
+		}
+
+		var norm float64
+		for _, value := range vector {
+			norm += float64(value * value)
+		}
+
+		if norm == 0 {
+			// Vectors can't be normalized if they're all zeros
+			vector[0] = 1
+			return vector, nil
+		}
+
+		for i := range vector {
+			vector[i] /= float32(math.Sqrt(norm))
+		}
+
+		return vector, nil
+	}
+}
+
+// hashingWords splits text into lowercase words, including the words of identifiers,
+// e.g., "getUserName()" -> getusername, get, user, name
+func hashingWords(text string) []string {
+	identifiers := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+
+	var words []string
+	for _, identifier := range identifiers {
+		parts := parser.SplitIdentifier(identifier)
+		if len(parts) > 1 {
+			words = append(words, strings.ToLower(identifier))
+		}
+
+		for _, part := range parts {
+			if len(part) > 1 {
+				words = append(words, part)
+			}
+		}
+	}
+
+	return words
+}
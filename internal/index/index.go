@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/cespare/xxhash"
 	"github.com/philippgille/chromem-go"
 	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
 )
@@ -16,23 +19,58 @@ import (
 const (
 	minSimilarity = 0.3
 	maxResults    = 30
+
+	// indexFormat is bumped when what gets embedded or stored changes so that stale indexes are rebuilt
+	indexFormat = "7"
+
+	defaultDBPath = ".sourcerer/db"
 )
 
+// Options configures an Index, zero values fall back to defaults
+type Options struct {
+	DBPath string // where the vector db is persisted, defaults to .sourcerer/db
+	// Embedder defaults to OpenAI's API. Embeddings are cached by content,
+	// so a db should only ever be used with one embedder.
+	Embedder chromem.EmbeddingFunc
+}
+
+type fileState struct {
+	parsedAt int64
+	hash     string // content hash of the indexed file
+}
+
 type Index struct {
 	workspaceRoot string
+	opts          Options
 	collection    *chromem.Collection
+	embeddings    *chromem.Collection // cached embeddings keyed by the content hash of what was embedded
 
-	cache   map[string]int64 // filePath -> max parsedAt timestamp
+	cache   map[string]fileState // filePath -> last indexed state
+	symbols symbolTable
 	cacheMu sync.RWMutex
 
+	staged   map[string]*stagedFile // filePath -> chunks pending (re)embedding
+	stagedMu sync.RWMutex
+
 	initOnce sync.Once
 	initErr  error
 }
 
-func New(ctx context.Context, workspaceRoot string) (*Index, error) {
+func New(ctx context.Context, workspaceRoot string, opts Options) (*Index, error) {
+	if opts.DBPath == "" {
+		opts.DBPath = defaultDBPath
+	}
+
+	if opts.Embedder == nil {
+		opts.Embedder = chromem.NewEmbeddingFuncDefault()
+	}
+
 	idx := &Index{
 		workspaceRoot: workspaceRoot,
-		cache:         map[string]int64{},
+		opts:          opts,
+		cache:         map[string]fileState{},
+		symbols:       symbolTable{},
+		staged:        map[string]*stagedFile{},
 	}
 
 	go idx.ensureInitialized(ctx)
@@ -42,19 +80,27 @@ func New(ctx context.Context, workspaceRoot string) (*Index, error) {
 
 func (idx *Index) ensureInitialized(ctx context.Context) error {
 	idx.initOnce.Do(func() {
-		db, err := chromem.NewPersistentDB(".sourcerer/db", false)
+		db, err := chromem.NewPersistentDB(idx.opts.DBPath, false)
 		if err != nil {
 			idx.initErr = fmt.Errorf("failed to create vector db: %w", err)
 			return
 		}
 
-		collection, err := db.GetOrCreateCollection("code-chunks", nil, nil)
+		embed := idx.opts.Embedder
+		embeddings, err := db.GetOrCreateCollection("embeddings", nil, embed)
+		if err != nil {
+			idx.initErr = fmt.Errorf("failed to create embeddings cache: %w", err)
+			return
+		}
+
+		collection, err := db.GetOrCreateCollection("code-chunks", nil, cachedEmbeddingFunc(embed, embeddings))
 		if err != nil {
 			idx.initErr = fmt.Errorf("failed to create vector db collection: %w", err)
 			return
 		}
 
 		idx.collection = collection
+		idx.embeddings = embeddings
 		idx.loadCache(ctx)
 	})
 
@@ -70,10 +116,18 @@ func (idx *Index) loadCache(ctx context.Context) {
 		return
 	}
 
-	fileMaxParsed := make(map[string]int64)
+	fileStates := make(map[string]fileState)
+	symbols := symbolTable{}
 	for _, doc := range docs {
+		if doc.Metadata["format"] != indexFormat {
+			// Leave the file uncached so that it's reindexed
+			continue
+		}
+
+		symbols.addDoc(doc)
+
 		filePath := doc.Metadata["file"]
-		_, exists := fileMaxParsed[filePath]
+		_, exists := fileStates[filePath]
 		if exists {
 			continue
 		}
@@ -83,27 +137,62 @@ func (idx *Index) loadCache(ctx context.Context) {
 			continue
 		}
 
-		fileMaxParsed[filePath] = parsedAt
+		fileStates[filePath] = fileState{
+			parsedAt: parsedAt,
+			hash:     doc.Metadata["fileHash"],
+		}
 	}
 
-	idx.cache = fileMaxParsed
+	idx.cache = fileStates
+	idx.symbols = symbols
 }
 
 func (idx *Index) IsStale(ctx context.Context, filePath string) bool {
-	fileInfo, err := os.Stat(filePath)
+	fullPath := filepath.Join(idx.workspaceRoot, filePath)
+	fileInfo, err := os.Stat(fullPath)
 	if err != nil {
 		return true
 	}
 
 	idx.cacheMu.RLock()
-	defer idx.cacheMu.RUnlock()
+	state, exists := idx.cache[filePath]
+	idx.cacheMu.RUnlock()
 
-	maxParsedAt, exists := idx.cache[filePath]
 	if !exists {
 		return true
 	}
 
-	return fileInfo.ModTime().Unix() > maxParsedAt
+	if fileInfo.ModTime().Unix() <= state.parsedAt {
+		return false
+	}
+
+	// Touched since it was indexed, e.g., by a branch switch, but the content
+	// might still be what we indexed
+	source, err := os.ReadFile(fullPath)
+	if err != nil || state.hash == "" || hashContent(source) != state.hash {
+		return true
+	}
+
+	idx.cacheMu.Lock()
+	defer idx.cacheMu.Unlock()
+
+	state.parsedAt = fileInfo.ModTime().Unix()
+	idx.cache[filePath] = state
+
+	return false
+}
+
+// Files returns the content hashes of all indexed files, keyed by file path
+func (idx *Index) Files() map[string]string {
+	idx.cacheMu.RLock()
+	defer idx.cacheMu.RUnlock()
+
+	files := make(map[string]string, len(idx.cache))
+	for filePath, state := range idx.cache {
+		files[filePath] = state.hash
+	}
+
+	return files
 }
 
 func (idx *Index) Index(ctx context.Context, file *parser.File) error {
@@ -112,34 +201,34 @@ func (idx *Index) Index(ctx context.Context, file *parser.File) error {
 		return err
 	}
 
-	err = idx.Remove(ctx, file.Path)
+	err = idx.remove(ctx, file.Path)
 	if err != nil {
 		return err
 	}
 
+	fileHash := hashContent(file.Source)
+	defer idx.unstage(file.Path, fileHash)
+
 	if len(file.Chunks) == 0 {
 		return nil
 	}
 
+	// Oversized chunks are embedded as parts, then stored with the sum of their parts'
+	// embeddings so that they can still be looked up & compared by ID
 	docs := []chromem.Document{}
+	splitChunks := []*parser.Chunk{}
 	for _, chunk := range file.Chunks {
-		doc := chromem.Document{
-			ID: chunk.ID(),
-			Metadata: map[string]string{
-				"file":        file.Path,
-				"type":        chunk.Type,
-				"path":        chunk.Path,
-				"summary":     chunk.Summary,
-				"startLine":   strconv.Itoa(int(chunk.StartLine)),
-				"startColumn": strconv.Itoa(int(chunk.StartColumn)),
-				"endLine":     strconv.Itoa(int(chunk.EndLine)),
-				"endColumn":   strconv.Itoa(int(chunk.EndColumn)),
-				"parsedAt":    strconv.FormatInt(chunk.ParsedAt, 10),
-			},
-			Content: chunk.Source,
+		if len(chunk.Parts) == 0 {
+			docs = append(docs, chunkDoc(chunk, fileHash))
+			continue
 		}
 
-		docs = append(docs, doc)
+		for _, part := range chunk.Parts {
+			doc := chunkDoc(part, fileHash)
+			doc.Metadata["parent"] = chunk.ID()
+			docs = append(docs, doc)
+		}
+		splitChunks = append(splitChunks, chunk)
 	}
 
 	err = idx.collection.AddDocuments(ctx, docs, runtime.NumCPU())
@@ -147,24 +236,123 @@ func (idx *Index) Index(ctx context.Context, file *parser.File) error {
 		return fmt.Errorf("failed to add documents to vector db: %w", err)
 	}
 
+	if len(splitChunks) > 0 {
+		splitDocs := []chromem.Document{}
+		for _, chunk := range splitChunks {
+			doc := chunkDoc(chunk, fileHash)
+			doc.Metadata["parts"] = strconv.Itoa(len(chunk.Parts))
+			doc.Embedding, err = idx.sumEmbeddings(ctx, chunk.Parts)
+			if err != nil {
+				return err
+			}
+
+			splitDocs = append(splitDocs, doc)
+		}
+
+		err = idx.collection.AddDocuments(ctx, splitDocs, runtime.NumCPU())
+		if err != nil {
+			return fmt.Errorf("failed to add documents to vector db: %w", err)
+		}
+	}
+
 	idx.cacheMu.Lock()
 	defer idx.cacheMu.Unlock()
 
 	if len(file.Chunks) > 0 {
-		idx.cache[file.Path] = file.Chunks[0].ParsedAt
+		idx.cache[file.Path] = fileState{
+			parsedAt: file.Chunks[0].ParsedAt,
+			hash:     fileHash,
+		}
+	}
+
+	for _, chunk := range file.Chunks {
+		idx.symbols.add(chunk)
 	}
 
 	return nil
 }
 
+// chunkDoc converts a chunk into a vector db document.
+// The content is what gets embedded, the raw source is kept in the metadata.
+func chunkDoc(chunk *parser.Chunk, fileHash string) chromem.Document {
+	content := chunk.EmbeddingText
+	if content == "" {
+		content = chunk.Source
+	}
+
+	return chromem.Document{
+		ID: chunk.ID(),
+		Metadata: map[string]string{
+			"file":        chunk.File,
+			"type":        chunk.Type,
+			"path":        chunk.Path,
+			"name":        chunk.Name,
+			"kind":        chunk.Kind,
+			"language":    chunk.Language,
+			"signature":   chunk.Signature,
+			"description": chunk.Description,
+			"summary":     chunk.Summary,
+			"aliases":     strings.Join(chunk.Aliases, ","),
+			"package":     chunk.Package,
+			"importPath":  chunk.ImportPath,
+			"startLine":   strconv.Itoa(int(chunk.StartLine)),
+			"startColumn": strconv.Itoa(int(chunk.StartColumn)),
+			"endLine":     strconv.Itoa(int(chunk.EndLine)),
+			"endColumn":   strconv.Itoa(int(chunk.EndColumn)),
+			"parsedAt":    strconv.FormatInt(chunk.ParsedAt, 10),
+			"fileHash":    fileHash,
+			"source":      chunk.Source,
+			"codeHash":    strconv.FormatUint(xxhash.Sum64String(normalizeCode(chunk.Code, chunk.Name)), 16),
+			"format":      indexFormat,
+		},
+		Content: content,
+	}
+}
+
+// sumEmbeddings adds up the stored embeddings of chunks, the vector db normalizes the result
+func (idx *Index) sumEmbeddings(ctx context.Context, chunks []*parser.Chunk) ([]float32, error) {
+	var sum []float32
+	for _, chunk := range chunks {
+		part, err := idx.collection.GetByID(ctx, chunk.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get embedding of %s: %w", chunk.ID(), err)
+		}
+
+		if sum == nil {
+			sum = make([]float32, len(part.Embedding))
+		}
+
+		for j, value := range part.Embedding {
+			sum[j] += value
+		}
+	}
+
+	return sum, nil
+}
+
 func (idx *Index) Remove(ctx context.Context, filePath string) error {
 	err := idx.ensureInitialized(ctx)
 	if err != nil {
 		return err
 	}
 
+	err = idx.remove(ctx, filePath)
+	if err != nil {
+		return err
+	}
+
+	idx.stagedMu.Lock()
+	defer idx.stagedMu.Unlock()
+
+	delete(idx.staged, filePath)
+
+	return nil
+}
+
+// remove drops a file's chunks from the index, leaving any staged version in place
+func (idx *Index) remove(ctx context.Context, filePath string) error {
 	where := map[string]string{"file": filePath}
-	err = idx.collection.Delete(ctx, where, nil)
+	err := idx.collection.Delete(ctx, where, nil)
 	if err != nil {
 		return fmt.Errorf("failed to remove documents from vector db: %w", err)
 	}
@@ -173,11 +361,49 @@ func (idx *Index) Remove(ctx context.Context, filePath string) error {
 	defer idx.cacheMu.Unlock()
 
 	delete(idx.cache, filePath)
+	delete(idx.symbols, filePath)
 
 	return nil
 }
 
-func (idx *Index) Search(ctx context.Context, query string, fileTypes []string) ([]string, error) {
+// RemoveDir removes all indexed files under dirPath
+func (idx *Index) RemoveDir(ctx context.Context, dirPath string) error {
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return err
+	}
+
+	prefix := strings.TrimSuffix(dirPath, "/") + "/"
+
+	idx.cacheMu.RLock()
+	var filePaths []string
+	for filePath := range idx.cache {
+		if strings.HasPrefix(filePath, prefix) {
+			filePaths = append(filePaths, filePath)
+		}
+	}
+	idx.cacheMu.RUnlock()
+
+	for _, filePath := range filePaths {
+		err := idx.Remove(ctx, filePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChunkFilter decides whether a chunk should be included in search results
+type ChunkFilter func(chunk *parser.Chunk) bool
+
+// SearchResult is a chunk that matched a search, results are ordered by relevance
+type SearchResult struct {
+	Chunk      *parser.Chunk
+	Similarity float32
+}
+
+func (idx *Index) Search(ctx context.Context, query string, fileTypes []string, filter ChunkFilter) ([]SearchResult, error) {
 	err := idx.ensureInitialized(ctx)
 	if err != nil {
 		return nil, err
@@ -189,6 +415,15 @@ func (idx *Index) Search(ctx context.Context, query string, fileTypes []string)
 
 	// chromem-go doesn't support OR filtering, for now fetch more & filter manually
 	nResults := min(len(fileTypes)*maxResults, idx.collection.Count())
+	if filter != nil {
+		// The filter can be arbitrarily narrow so consider everything
+		nResults = idx.collection.Count()
+	}
+
+	if nResults == 0 {
+		return []SearchResult{}, nil
+	}
+
 	results, err := idx.collection.Query(ctx, query, nResults, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform similarity search: %w", err)
@@ -199,10 +434,14 @@ func (idx *Index) Search(ctx context.Context, query string, fileTypes []string)
 		allowedTypes[ft] = true
 	}
 
-	return idx.formatSearchResults(ctx, results, minSimilarity, maxResults, "", allowedTypes), nil
+	typeFilter := func(chunk *parser.Chunk) bool {
+		return allowedTypes[chunk.Type] && (filter == nil || filter(chunk))
+	}
+
+	return idx.collectResults(ctx, results, minSimilarity, maxResults, "", typeFilter), nil
 }
 
-func (idx *Index) FindSimilarChunks(ctx context.Context, chunkID string) ([]string, error) {
+func (idx *Index) FindSimilarChunks(ctx context.Context, chunkID string) ([]SearchResult, error) {
 	err := idx.ensureInitialized(ctx)
 	if err != nil {
 		return nil, err
@@ -218,28 +457,34 @@ func (idx *Index) FindSimilarChunks(ctx context.Context, chunkID string) ([]stri
 		return nil, fmt.Errorf("failed to perform similarity search: %w", err)
 	}
 
-	return idx.formatSearchResults(ctx, results, 2*minSimilarity, 10, chunkID, nil), nil
+	return idx.collectResults(ctx, results, 2*minSimilarity, 10, chunkID, nil), nil
 }
 
-func (idx *Index) formatSearchResults(
+func (idx *Index) collectResults(
 	ctx context.Context,
 	results []chromem.Result,
 	minSimilarity float32,
 	maxCount int,
 	skipID string,
-	typeFilter map[string]bool,
-) []string {
+	filter ChunkFilter,
+) []SearchResult {
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Similarity > results[j].Similarity
 	})
 
-	paths := []string{}
+	collected := []SearchResult{}
 	for _, result := range results {
-		if result.ID == skipID {
+		// Split chunks are searched through their parts
+		if result.ID == skipID || result.Metadata["parts"] != "" {
+			continue
+		}
+
+		// Parts of the chunk being compared against are trivially similar to it
+		if skipID != "" && result.Metadata["parent"] == skipID {
 			continue
 		}
 
-		if result.Similarity < minSimilarity || len(paths) >= maxCount {
+		if result.Similarity < minSimilarity || len(collected) >= maxCount {
 			break
 		}
 
@@ -248,27 +493,43 @@ func (idx *Index) formatSearchResults(
 			continue
 		}
 
-		if typeFilter != nil && !typeFilter[chunk.Type] {
+		if filter != nil && !filter(chunk) {
 			continue
 		}
 
-		var lines string
-		if chunk.StartLine == chunk.EndLine {
-			lines = fmt.Sprintf("line %d", chunk.StartLine)
-		} else {
-			lines = fmt.Sprintf("lines %d-%d", chunk.StartLine, chunk.EndLine)
-		}
+		collected = append(collected, SearchResult{Chunk: chunk, Similarity: result.Similarity})
+	}
+
+	return collected
+}
 
-		paths = append(
-			paths,
-			fmt.Sprintf("%s | %s [%s]", result.ID, chunk.Summary, lines),
-		)
+// FormatResults renders search results as "id | summary [lines, ~tokens]"
+func FormatResults(results []SearchResult) []string {
+	formatted := make([]string, 0, len(results))
+	for _, result := range results {
+		formatted = append(formatted, FormatChunk(result.Chunk))
 	}
 
-	return paths
+	return formatted
+}
+
+// FormatChunk renders a chunk as "id | summary [lines, ~tokens]"
+func FormatChunk(chunk *parser.Chunk) string {
+	return fmt.Sprintf("%s | %s [%s, ~%d tokens]", chunk.ID(), chunk.Summary, chunk.Lines(), chunk.Tokens())
 }
 
 func (idx *Index) GetChunk(ctx context.Context, id string) (*parser.Chunk, error) {
+	filePath, _, _ := strings.Cut(id, "::")
+	chunk, staged := idx.getStagedChunk(filePath, id)
+	if staged {
+		// The staged version of the file is newer than what's embedded
+		if chunk == nil {
+			return nil, fmt.Errorf("chunk not found: %s", id)
+		}
+
+		return chunk, nil
+	}
+
 	err := idx.ensureInitialized(ctx)
 	if err != nil {
 		return nil, err
@@ -279,24 +540,76 @@ func (idx *Index) GetChunk(ctx context.Context, id string) (*parser.Chunk, error
 		return nil, fmt.Errorf("chunk not found: %s", id)
 	}
 
+	return chunkFromDoc(&doc), nil
+}
+
+// NamedChunks returns the indexed named chunks of a file type, e.g., all test functions.
+// Split chunks are returned as a whole rather than as their parts.
+func (idx *Index) NamedChunks(ctx context.Context, fileType string) ([]*parser.Chunk, error) {
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := idx.collection.ListDocumentsShallow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var chunks []*parser.Chunk
+	for _, doc := range docs {
+		if doc.Metadata["format"] != indexFormat || doc.Metadata["parent"] != "" {
+			continue
+		}
+
+		if doc.Metadata["type"] != fileType || doc.Metadata["name"] == "" {
+			continue
+		}
+
+		chunks = append(chunks, chunkFromDoc(doc))
+	}
+
+	return chunks, nil
+}
+
+func chunkFromDoc(doc *chromem.Document) *parser.Chunk {
 	startLine, _ := strconv.Atoi(doc.Metadata["startLine"])
 	startColumn, _ := strconv.Atoi(doc.Metadata["startColumn"])
 	endLine, _ := strconv.Atoi(doc.Metadata["endLine"])
 	endColumn, _ := strconv.Atoi(doc.Metadata["endColumn"])
 	parsedAt, _ := strconv.ParseInt(doc.Metadata["parsedAt"], 10, 64)
 
+	// Documents from before the source was stored separately embedded the raw source
+	source, exists := doc.Metadata["source"]
+	if !exists {
+		source = doc.Content
+	}
+
+	var aliases []string
+	if doc.Metadata["aliases"] != "" {
+		aliases = strings.Split(doc.Metadata["aliases"], ",")
+	}
+
 	return &parser.Chunk{
 		File:        doc.Metadata["file"],
 		Type:        doc.Metadata["type"],
 		Path:        doc.Metadata["path"],
+		Name:        doc.Metadata["name"],
+		Kind:        doc.Metadata["kind"],
+		Language:    doc.Metadata["language"],
+		Signature:   doc.Metadata["signature"],
+		Description: doc.Metadata["description"],
 		Summary:     doc.Metadata["summary"],
-		Source:      doc.Content,
+		Aliases:     aliases,
+		Source:      source,
+		Package:     doc.Metadata["package"],
+		ImportPath:  doc.Metadata["importPath"],
 		StartLine:   uint(startLine),
 		StartColumn: uint(startColumn),
 		EndLine:     uint(endLine),
 		EndColumn:   uint(endColumn),
 		ParsedAt:    parsedAt,
-	}, nil
+	}
 }
 
 func (idx *Index) CleanupDeletedFiles(ctx context.Context) {
@@ -310,9 +623,9 @@ func (idx *Index) CleanupDeletedFiles(ctx context.Context) {
 
 	for filePath := range idx.cache {
 		go func(path string) {
-			_, err := os.Stat(filePath)
+			_, err := os.Stat(filepath.Join(idx.workspaceRoot, path))
 			if os.IsNotExist(err) {
-				idx.Remove(ctx, filePath)
+				idx.Remove(ctx, path)
 			}
 		}(filePath)
 	}
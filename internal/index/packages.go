@@ -0,0 +1,113 @@
+package index
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// inPackage reports whether a chunk belongs to the package named by qualifier,
+// i.e., its import path, its directory, or its package name
+func inPackage(chunk *parser.Chunk, qualifier string) bool {
+	// Python modules only have import paths, e.g., pkg.models
+	if chunk.Package == "" {
+		return chunk.ImportPath != "" && qualifier == chunk.ImportPath
+	}
+
+	return qualifier == chunk.ImportPath || qualifier == path.Dir(chunk.File) || qualifier == chunk.Package
+}
+
+// samePackage reports whether two chunks belong to the same package, or file if there are no packages
+func samePackage(a, b *parser.Chunk) bool {
+	if a.Package == "" || b.Package == "" {
+		return a.File == b.File
+	}
+
+	return a.Package == b.Package && path.Dir(a.File) == path.Dir(b.File)
+}
+
+// ResolvePackageID resolves a package-qualified ID, e.g., pkg::Type::method, where the package
+// is its name, directory, or import path, to the chunk it names. ok is false if the ID isn't
+// package-qualified, i.e., it starts with an indexed file.
+func (idx *Index) ResolvePackageID(ctx context.Context, id string) (resolution Resolution, ok bool) {
+	qualifier, chunkPath, found := strings.Cut(id, "::")
+	if !found || idx.ensureInitialized(ctx) != nil {
+		return Resolution{}, false
+	}
+
+	idx.cacheMu.RLock()
+	defer idx.cacheMu.RUnlock()
+
+	_, indexed := idx.cache[qualifier]
+	if indexed {
+		return Resolution{}, false
+	}
+
+	var matches []string
+	for _, symbols := range idx.symbols {
+		for _, symbol := range symbols {
+			if !inPackage(symbol, qualifier) {
+				continue
+			}
+
+			ok = true
+			if symbol.Path == chunkPath {
+				matches = append(matches, symbol.ID())
+			}
+		}
+	}
+	sort.Strings(matches)
+
+	switch {
+	case len(matches) == 1:
+		return Resolution{ID: matches[0]}, ok
+	case len(matches) > 1:
+		// e.g., package main in several directories
+		return Resolution{Suggestions: matches[:min(len(matches), maxSuggestions)]}, ok
+	}
+
+	return Resolution{}, ok
+}
+
+// Methods returns the methods of a type across its package's files, e.g., Go methods
+// declared next to their callers, ordered by file & line
+func (idx *Index) Methods(ctx context.Context, typeChunk *parser.Chunk) ([]*parser.Chunk, error) {
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idx.cacheMu.RLock()
+	var methods []*parser.Chunk
+	for _, symbols := range idx.symbols {
+		for _, symbol := range symbols {
+			name, found := strings.CutPrefix(symbol.Path, typeChunk.Path+"::")
+			if !found || strings.Contains(name, "::") {
+				continue
+			}
+
+			if isCallable(symbol.Kind) && samePackage(symbol, typeChunk) {
+				methods = append(methods, symbol)
+			}
+		}
+	}
+	idx.cacheMu.RUnlock()
+
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].File != methods[j].File {
+			return methods[i].File < methods[j].File
+		}
+
+		return methods[i].StartLine < methods[j].StartLine
+	})
+
+	return methods, nil
+}
+
+// isCallable reports whether a chunk kind declares a function or method
+func isCallable(kind string) bool {
+	return strings.Contains(kind, "method") || strings.Contains(kind, "function")
+}
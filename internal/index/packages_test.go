@@ -0,0 +1,113 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePackageID(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"store/cart.go": `package store
+
+type Cart struct{}
+
+func (c *Cart) Add(item string) {}
+`,
+		"store/cart_total.go": `package store
+
+func (c *Cart) Total() int { return 0 }
+`,
+		"cmd/a/main.go": "package main\n\nfunc run() {}\n",
+		"cmd/b/main.go": "package main\n\nfunc run() {}\n",
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		id          string
+		qualified   bool
+		expected    string
+		suggestions []string
+	}{
+		{name: "package name", id: "store::Cart::Total", qualified: true, expected: "store/cart_total.go::Cart::Total"},
+		{name: "package directory", id: "store::Cart", qualified: true, expected: "store/cart.go::Cart"},
+		{
+			name:        "ambiguous package name",
+			id:          "main::run",
+			qualified:   true,
+			suggestions: []string{"cmd/a/main.go::run", "cmd/b/main.go::run"},
+		},
+		{name: "unknown chunk", id: "store::Checkout", qualified: true},
+		{name: "file", id: "store/cart.go::Cart::Total", qualified: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, qualified := idx.ResolvePackageID(ctx, tt.id)
+			assert.Equal(t, tt.qualified, qualified)
+			assert.Equal(t, tt.expected, resolution.ID)
+			assert.Equal(t, tt.suggestions, resolution.Suggestions)
+		})
+	}
+
+	assert.Equal(t, "store/cart_total.go::Cart::Total", idx.Resolve(ctx, "store::Cart::Total").ID)
+}
+
+func TestMethods(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"store/cart.go": `package store
+
+type Cart struct{}
+
+func (c *Cart) Add(item string) {}
+
+func (c *Cart) Remove(item string) {}
+`,
+		"store/cart_total.go": `package store
+
+func (c *Cart) Total() int { return 0 }
+
+func (o *Order) Total() int { return 0 }
+`,
+		"other/cart.go": `package other
+
+type Cart struct{}
+
+func (c *Cart) Clear() {}
+`,
+	})
+	ctx := context.Background()
+
+	cart, err := idx.GetChunk(ctx, "store/cart.go::Cart")
+	require.NoError(t, err)
+
+	methods, err := idx.Methods(ctx, cart)
+	require.NoError(t, err)
+
+	ids := make([]string, len(methods))
+	for i, method := range methods {
+		ids[i] = method.ID()
+	}
+
+	assert.Equal(t, []string{
+		"store/cart.go::Cart::Add",
+		"store/cart.go::Cart::Remove",
+		"store/cart_total.go::Cart::Total",
+	}, ids)
+}
+
+func TestFindSymbolByPackage(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"store/cart.go":  "package store\n\ntype Cart struct{}\n\nfunc (c *Cart) Add() {}\n",
+		"other/other.go": "package other\n\ntype Cart struct{}\n\nfunc (c *Cart) Add() {}\n",
+	})
+
+	matches, err := idx.FindSymbol(context.Background(), SymbolQuery{Name: "store.Cart.Add"})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "store/cart.go::Cart::Add", matches[0].Chunk.ID())
+	assert.Equal(t, MatchExact, matches[0].Mode)
+}
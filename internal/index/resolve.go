@@ -0,0 +1,220 @@
+package index
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+const maxSuggestions = 5
+
+// duplicateSuffix matches the counter appended to duplicate chunk paths, e.g., "Foo-2"
+var duplicateSuffix = regexp.MustCompile(`-\d+$`)
+
+// Resolution is the outcome of resolving a chunk ID that doesn't exist
+type Resolution struct {
+	ID          string   // the resolved chunk ID, empty if there's no unique match
+	Suggestions []string // "did you mean" chunk IDs when there's no unique match
+}
+
+// IDs returns the IDs of all indexed & staged chunks
+func (idx *Index) IDs(ctx context.Context) []string {
+	seen := map[string]bool{}
+	var ids []string
+
+	idx.stagedMu.RLock()
+	for _, file := range idx.staged {
+		for id := range file.chunks {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	stagedFiles := make(map[string]bool, len(idx.staged))
+	for filePath := range idx.staged {
+		stagedFiles[filePath] = true
+	}
+	idx.stagedMu.RUnlock()
+
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return ids
+	}
+
+	for _, id := range idx.collection.ListIDs(ctx) {
+		filePath, _, _ := strings.Cut(id, "::")
+		if !seen[id] && !stagedFiles[filePath] {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// Resolve finds the chunk an agent most likely meant by a chunk ID that doesn't exist
+// (typos, methods moved to other files, changed duplicate suffixes, etc), trying in order:
+//  1. a package-qualified ID, e.g., pkg::Type::method
+//  2. a name declared by a chunk declaring several, e.g., Point::Y for X, Y int
+//  3. a case-insensitive match
+//  4. the same chunk path (ignoring case & duplicate suffixes) in other files
+//  5. chunk paths within the same file that are a small edit distance away
+func (idx *Index) Resolve(ctx context.Context, id string) Resolution {
+	resolution, qualified := idx.ResolvePackageID(ctx, id)
+	if qualified && (resolution.ID != "" || len(resolution.Suggestions) > 0) {
+		return resolution
+	}
+
+	filePath, chunkPath, _ := strings.Cut(id, "::")
+	aliased := idx.resolveAlias(filePath, chunkPath)
+	if aliased != "" {
+		return Resolution{ID: aliased}
+	}
+
+	ids := idx.IDs(ctx)
+
+	var caseMatches, pathMatches []string
+	type candidate struct {
+		id       string
+		distance int
+	}
+	var nearby []candidate
+
+	normalized := normalizeChunkPath(chunkPath)
+	maxDistance := max(2, len(chunkPath)/4)
+	for _, other := range ids {
+		if strings.EqualFold(other, id) {
+			caseMatches = append(caseMatches, other)
+			continue
+		}
+
+		otherFile, otherPath, _ := strings.Cut(other, "::")
+		if normalizeChunkPath(otherPath) == normalized {
+			pathMatches = append(pathMatches, other)
+			continue
+		}
+
+		if otherFile == filePath {
+			distance := levenshtein(strings.ToLower(chunkPath), strings.ToLower(otherPath))
+			if distance <= maxDistance {
+				nearby = append(nearby, candidate{other, distance})
+			}
+		}
+	}
+
+	sort.Strings(caseMatches)
+	sort.Strings(pathMatches)
+	sort.Slice(nearby, func(i, j int) bool {
+		if nearby[i].distance != nearby[j].distance {
+			return nearby[i].distance < nearby[j].distance
+		}
+
+		return nearby[i].id < nearby[j].id
+	})
+
+	nearbyIDs := make([]string, 0, len(nearby))
+	for _, c := range nearby {
+		nearbyIDs = append(nearbyIDs, c.id)
+	}
+
+	for _, matches := range [][]string{caseMatches, pathMatches, nearbyIDs} {
+		switch {
+		case len(matches) == 1:
+			return Resolution{ID: matches[0]}
+		case len(matches) > 1:
+			return Resolution{Suggestions: matches[:min(len(matches), maxSuggestions)]}
+		}
+	}
+
+	return Resolution{}
+}
+
+// resolveAlias finds the chunk of a file that declares chunkPath's name alongside others,
+// e.g., Point::X for Point::Y when the struct has an X, Y int field
+func (idx *Index) resolveAlias(filePath, chunkPath string) string {
+	parentPath, name := "", chunkPath
+	separator := strings.LastIndex(chunkPath, "::")
+	if separator >= 0 {
+		parentPath, name = chunkPath[:separator], chunkPath[separator+2:]
+	}
+
+	for _, chunk := range idx.fileSymbols(filePath) {
+		if !slices.Contains(chunk.Aliases, name) {
+			continue
+		}
+
+		chunkParent := ""
+		separator := strings.LastIndex(chunk.Path, "::")
+		if separator >= 0 {
+			chunkParent = chunk.Path[:separator]
+		}
+
+		if chunkParent == parentPath {
+			return chunk.ID()
+		}
+	}
+
+	return ""
+}
+
+// fileSymbols returns the named chunks of a file, preferring its staged version
+func (idx *Index) fileSymbols(filePath string) []*parser.Chunk {
+	idx.stagedMu.RLock()
+	staged, isStaged := idx.staged[filePath]
+	idx.stagedMu.RUnlock()
+
+	if isStaged {
+		var symbols []*parser.Chunk
+		for _, chunk := range staged.chunks {
+			if chunk.Name != "" {
+				symbols = append(symbols, chunk)
+			}
+		}
+
+		return symbols
+	}
+
+	idx.cacheMu.RLock()
+	defer idx.cacheMu.RUnlock()
+
+	return idx.symbols[filePath]
+}
+
+// normalizeChunkPath strips casing & duplicate suffixes from each path segment
+func normalizeChunkPath(chunkPath string) string {
+	segments := strings.Split(strings.ToLower(chunkPath), "::")
+	for i, segment := range segments {
+		segments[i] = duplicateSuffix.ReplaceAllString(segment, "")
+	}
+
+	return strings.Join(segments, "::")
+}
+
+// levenshtein returns the edit distance between a & b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,91 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"shop/cart.go": `package shop
+
+type Cart struct{}
+
+func (c *Cart) Total() int { return 0 }
+
+func Checkout() {}
+
+func Checkouts() {}
+`,
+		"shop/cart_remove.go": `package shop
+
+func (c *Cart) Remove(item string) {}
+`,
+		"orders/cart.go": `package orders
+
+type Cart struct{}
+`,
+		"shop/box.go": `package shop
+
+type Box struct {
+	Width, Height int
+}
+`,
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		id          string
+		expected    string
+		suggestions []string
+	}{
+		{name: "name declared alongside others", id: "shop/box.go::Box::Height", expected: "shop/box.go::Box::Width"},
+		{name: "case-insensitive match", id: "shop/cart.go::cart::total", expected: "shop/cart.go::Cart::Total"},
+		{name: "case-insensitive match over other files", id: "shop/cart.go::cart", expected: "shop/cart.go::Cart"},
+		{name: "path in another file", id: "shop/cart.go::Cart::Remove", expected: "shop/cart_remove.go::Cart::Remove"},
+		{name: "duplicate suffix over edit distance", id: "shop/cart.go::Checkout-2", expected: "shop/cart.go::Checkout"},
+		{
+			name:        "path in several files",
+			id:          "shop/order.go::Cart",
+			suggestions: []string{"orders/cart.go::Cart", "shop/cart.go::Cart"},
+		},
+		{name: "edit distance", id: "shop/cart.go::Cart::Totl", expected: "shop/cart.go::Cart::Total"},
+		{
+			name:        "several within edit distance",
+			id:          "shop/cart.go::Checkou",
+			suggestions: []string{"shop/cart.go::Checkout", "shop/cart.go::Checkouts"},
+		},
+		{name: "no match", id: "shop/cart.go::Payment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution := idx.Resolve(ctx, tt.id)
+			assert.Equal(t, tt.expected, resolution.ID)
+			assert.Equal(t, tt.suggestions, resolution.Suggestions)
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"Total", "Total", 0},
+		{"Total", "Totl", 1},
+		{"Total", "Totals", 1},
+		{"Total", "Toal", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"héllo", "hello", 1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, levenshtein(tt.a, tt.b), "%s -> %s", tt.a, tt.b)
+	}
+}
@@ -0,0 +1,79 @@
+package index
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// stagedFile holds the chunks of a file that was parsed on the read path
+// but hasn't been (re)embedded yet
+type stagedFile struct {
+	hash   string
+	chunks map[string]*parser.Chunk // chunk ID -> chunk
+}
+
+// Stage keeps a parsed file's chunks in memory so that they can be served
+// before the file is (re)embedded
+func (idx *Index) Stage(file *parser.File) {
+	staged := &stagedFile{
+		hash:   hashContent(file.Source),
+		chunks: make(map[string]*parser.Chunk, len(file.Chunks)),
+	}
+
+	for _, chunk := range file.Chunks {
+		staged.chunks[chunk.ID()] = chunk
+		for _, part := range chunk.Parts {
+			staged.chunks[part.ID()] = part
+		}
+	}
+
+	idx.stagedMu.Lock()
+	defer idx.stagedMu.Unlock()
+
+	idx.staged[file.Path] = staged
+}
+
+// IsStaged reports whether the staged chunks of a file reflect its current content
+func (idx *Index) IsStaged(filePath string) bool {
+	idx.stagedMu.RLock()
+	staged, exists := idx.staged[filePath]
+	idx.stagedMu.RUnlock()
+
+	if !exists {
+		return false
+	}
+
+	source, err := os.ReadFile(filepath.Join(idx.workspaceRoot, filePath))
+	if err != nil {
+		return false
+	}
+
+	return hashContent(source) == staged.hash
+}
+
+// unstage drops a file's staged chunks once the version with the given hash is indexed,
+// keeping newer versions that were staged while it was being embedded
+func (idx *Index) unstage(filePath, hash string) {
+	idx.stagedMu.Lock()
+	defer idx.stagedMu.Unlock()
+
+	staged, exists := idx.staged[filePath]
+	if exists && staged.hash == hash {
+		delete(idx.staged, filePath)
+	}
+}
+
+// getStagedChunk looks up a chunk of a staged file, staged is false if the file isn't staged
+func (idx *Index) getStagedChunk(filePath, id string) (chunk *parser.Chunk, staged bool) {
+	idx.stagedMu.RLock()
+	defer idx.stagedMu.RUnlock()
+
+	file, staged := idx.staged[filePath]
+	if !staged {
+		return nil, false
+	}
+
+	return file.chunks[id], true
+}
@@ -0,0 +1,34 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnstage(t *testing.T) {
+	idx := newTestIndex(t, nil)
+	ctx := context.Background()
+
+	older := &parser.File{Path: "cart.go", Source: []byte("package cart\n")}
+	newer := &parser.File{Path: "cart.go", Source: []byte("package cart\n\nfunc Total() int { return 0 }\n")}
+
+	// The newer version was staged while the older one was being embedded
+	idx.Stage(newer)
+	require.NoError(t, idx.Index(ctx, older))
+	_, staged := idx.getStagedChunk("cart.go", "cart.go::Total")
+	assert.True(t, staged)
+
+	require.NoError(t, idx.Index(ctx, newer))
+	_, staged = idx.getStagedChunk("cart.go", "cart.go::Total")
+	assert.False(t, staged)
+
+	// Removed files drop whichever version is staged
+	idx.Stage(newer)
+	require.NoError(t, idx.Remove(ctx, "cart.go"))
+	_, staged = idx.getStagedChunk("cart.go", "cart.go::Total")
+	assert.False(t, staged)
+}
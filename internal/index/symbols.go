@@ -0,0 +1,196 @@
+package index
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/philippgille/chromem-go"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+const defaultSymbolLimit = 20
+
+// Symbol match modes, from most to least precise
+const (
+	MatchExact  = "exact"
+	MatchPrefix = "prefix"
+	MatchFuzzy  = "fuzzy"
+)
+
+// SymbolQuery describes a symbol lookup
+type SymbolQuery struct {
+	Name     string // symbol name, optionally qualified, e.g., Analyzer::chunk, Analyzer.chunk or analyzer.Analyzer.chunk
+	Mode     string // exact, prefix, fuzzy, or empty to use the most precise mode with matches
+	Kind     string // optional chunk kind filter, e.g., function, method, class
+	Language string // optional language filter, e.g., go
+	Limit    int
+}
+
+// SymbolMatch is a named chunk whose path matched a symbol lookup
+type SymbolMatch struct {
+	Chunk *parser.Chunk
+	Mode  string
+	score int // lower is better
+}
+
+// symbolTable indexes the named chunks of each file by their path segments
+type symbolTable map[string][]*parser.Chunk // filePath -> named chunks (without source)
+
+func (t symbolTable) add(chunk *parser.Chunk) {
+	if chunk.Name == "" {
+		return
+	}
+
+	symbol := *chunk
+	symbol.Source = ""
+	t[chunk.File] = append(t[chunk.File], &symbol)
+}
+
+func (t symbolTable) addDoc(doc *chromem.Document) {
+	t.add(chunkFromDoc(doc))
+}
+
+// FindSymbol looks up named chunks by symbol name across the workspace
+func (idx *Index) FindSymbol(ctx context.Context, query SymbolQuery) ([]SymbolMatch, error) {
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	querySegments := splitSymbol(query.Name)
+	if len(querySegments) == 0 {
+		return nil, nil
+	}
+
+	kind := strings.ToLower(query.Kind)
+
+	idx.cacheMu.RLock()
+	var matches []SymbolMatch
+	for _, symbols := range idx.symbols {
+		for _, symbol := range symbols {
+			if kind != "" && !strings.Contains(strings.ToLower(symbol.Kind), kind) {
+				continue
+			}
+
+			if query.Language != "" && !strings.EqualFold(symbol.Language, query.Language) {
+				continue
+			}
+
+			// Packages qualify symbols, e.g., pkg.Type.Method
+			symbolPath := symbol.Path
+			if symbol.Package != "" {
+				symbolPath = symbol.Package + "::" + symbolPath
+			}
+
+			mode, score, ok := matchSymbol(querySegments, splitSymbol(symbolPath))
+
+			// Chunks declaring several names match any of them, e.g., Y in X, Y int
+			for _, alias := range symbol.Aliases {
+				aliasSegments := splitSymbol(symbolPath)
+				aliasSegments[len(aliasSegments)-1] = normalizeChunkPath(alias)
+
+				aliasMode, aliasScore, aliasOK := matchSymbol(querySegments, aliasSegments)
+				if aliasOK && (!ok || aliasScore < score) {
+					mode, score, ok = aliasMode, aliasScore, true
+				}
+			}
+
+			if ok {
+				matches = append(matches, SymbolMatch{Chunk: symbol, Mode: mode, score: score})
+			}
+		}
+	}
+	idx.cacheMu.RUnlock()
+
+	if query.Mode == "" {
+		matches = mostPreciseMatches(matches)
+	} else {
+		matches = filterMatches(matches, query.Mode)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+
+		return matches[i].Chunk.ID() < matches[j].Chunk.ID()
+	})
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSymbolLimit
+	}
+
+	return matches[:min(len(matches), limit)], nil
+}
+
+// matchSymbol matches query segments against the trailing segments of a chunk path.
+// Qualifiers (e.g., the type in Type::method) must match exactly while the
+// symbol name itself can match exactly, by prefix, or fuzzily.
+func matchSymbol(query, path []string) (string, int, bool) {
+	if len(query) > len(path) {
+		return "", 0, false
+	}
+
+	offset := len(path) - len(query)
+	for i := range len(query) - 1 {
+		if query[i] != path[offset+i] {
+			return "", 0, false
+		}
+	}
+
+	name := query[len(query)-1]
+	candidate := path[len(path)-1]
+	switch {
+	case candidate == name:
+		return MatchExact, 0, true
+	case strings.HasPrefix(candidate, name):
+		return MatchPrefix, 1 + len(candidate) - len(name), true
+	case strings.Contains(candidate, name):
+		return MatchFuzzy, 100 + len(candidate) - len(name), true
+	}
+
+	distance := levenshtein(name, candidate)
+	if distance <= max(1, len(name)/3) {
+		return MatchFuzzy, 100 + distance, true
+	}
+
+	return "", 0, false
+}
+
+func mostPreciseMatches(matches []SymbolMatch) []SymbolMatch {
+	for _, mode := range []string{MatchExact, MatchPrefix, MatchFuzzy} {
+		filtered := filterMatches(matches, mode)
+		if len(filtered) > 0 {
+			return filtered
+		}
+	}
+
+	return nil
+}
+
+func filterMatches(matches []SymbolMatch, mode string) []SymbolMatch {
+	var filtered []SymbolMatch
+	for _, match := range matches {
+		if match.Mode == mode {
+			filtered = append(filtered, match)
+		}
+	}
+
+	return filtered
+}
+
+// splitSymbol splits a (qualified) symbol or chunk path into normalized segments
+func splitSymbol(symbol string) []string {
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return nil
+	}
+
+	if !strings.Contains(symbol, "::") {
+		symbol = strings.ReplaceAll(symbol, ".", "::")
+	}
+
+	return strings.Split(normalizeChunkPath(symbol), "::")
+}
@@ -0,0 +1,44 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindSymbolAliases(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"shop/box.go": `package shop
+
+type Box struct {
+	Width, Height int
+}
+
+var minWidth, minHeight = 1, 1
+`,
+	})
+
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{name: "Box.Height", expected: []string{"shop/box.go::Box::Width"}},
+		{name: "minHeight", expected: []string{"shop/box.go::minWidth"}},
+		{name: "Box.Depth"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := idx.FindSymbol(context.Background(), SymbolQuery{Name: tt.name, Mode: MatchExact})
+			require.NoError(t, err)
+
+			var ids []string
+			for _, match := range matches {
+				ids = append(ids, match.Chunk.ID())
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
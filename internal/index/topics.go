@@ -0,0 +1,404 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"path"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+const (
+	DefaultTopicRepresentatives = 5
+
+	maxTopics        = 20
+	maxKMeansSamples = 5000 // centroids are fitted on a sample of large workspaces
+	maxKMeansRounds  = 30
+	topicKeywords    = 5
+	topicLabelWords  = 3
+	topicDirs        = 2
+
+	// kMeansSeed keeps topics stable across calls
+	kMeansSeed = 42
+)
+
+// codeWords are too common in code to tell topics apart
+var codeWords = map[string]bool{
+	"bool": true, "byte": true, "const": true, "context": true, "ctx": true, "def": true,
+	"err": true, "error": true, "func": true, "int": true, "new": true, "nil": true,
+	"none": true, "return": true, "self": true, "string": true, "struct": true,
+	"this": true, "var": true,
+}
+
+// TopicOptions configures topic clustering, zero values fall back to defaults
+type TopicOptions struct {
+	K               int      // number of topics, picked based on the workspace's size if 0
+	Dir             string   // only cluster chunks in this directory, e.g., to drill down
+	FileTypes       []string // defaults to src
+	Representatives int      // chunks listed per topic
+}
+
+// Topic is a group of semantically related chunks, e.g., a feature or subsystem
+type Topic struct {
+	Label           string
+	Keywords        []string        // distinctive words from the topic's identifiers & summaries
+	Dirs            []string        // directories holding most of the topic's chunks
+	Size            int             // number of chunks
+	Representatives []*parser.Chunk // chunks closest to the topic's centroid
+}
+
+// Topics clusters chunk embeddings into topics using spherical k-means, largest topics first
+func (idx *Index) Topics(ctx context.Context, opts TopicOptions) ([]Topic, error) {
+	err := idx.ensureInitialized(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.FileTypes) == 0 {
+		opts.FileTypes = []string{"src"}
+	}
+
+	if opts.Representatives <= 0 {
+		opts.Representatives = DefaultTopicRepresentatives
+	}
+
+	docs, err := idx.collection.ListDocumentsShallow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := strings.Trim(path.Clean("/"+opts.Dir), "/")
+	var chunks []*parser.Chunk
+	var embeddings [][]float32
+	for _, doc := range docs {
+		// Split chunks are clustered as a whole rather than through their parts
+		if doc.Metadata["format"] != indexFormat || doc.Metadata["parent"] != "" {
+			continue
+		}
+
+		if !slices.Contains(opts.FileTypes, doc.Metadata["type"]) {
+			continue
+		}
+
+		if dir != "" && !strings.HasPrefix(doc.Metadata["file"], dir+"/") {
+			continue
+		}
+
+		chunks = append(chunks, chunkFromDoc(doc))
+		embeddings = append(embeddings, doc.Embedding)
+	}
+
+	if len(chunks) == 0 {
+		return nil, nil
+	}
+
+	// Map iteration order is random, sort so that clustering is deterministic
+	order := make([]int, len(chunks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return chunks[order[i]].ID() < chunks[order[j]].ID()
+	})
+
+	sortedChunks := make([]*parser.Chunk, len(chunks))
+	sortedEmbeddings := make([][]float32, len(chunks))
+	for i, j := range order {
+		sortedChunks[i], sortedEmbeddings[i] = chunks[j], embeddings[j]
+	}
+	chunks, embeddings = sortedChunks, sortedEmbeddings
+
+	k := opts.K
+	if k <= 0 {
+		k = int(math.Round(math.Sqrt(float64(len(chunks)) / 2)))
+		k = max(2, min(k, maxTopics))
+	}
+	k = min(k, len(chunks))
+
+	centroids, assignments := kMeans(embeddings, k)
+	return describeTopics(chunks, embeddings, centroids, assignments, opts.Representatives), nil
+}
+
+// kMeans clusters normalized vectors by cosine similarity, returning the centroids
+// & the cluster of each vector
+func kMeans(vectors [][]float32, k int) ([][]float32, []int) {
+	rng := rand.New(rand.NewSource(kMeansSeed))
+
+	samples := vectors
+	if len(vectors) > maxKMeansSamples {
+		samples = make([][]float32, maxKMeansSamples)
+		for i, j := range rng.Perm(len(vectors))[:maxKMeansSamples] {
+			samples[i] = vectors[j]
+		}
+	}
+
+	centroids := seedCentroids(samples, k, rng)
+	assignments := make([]int, len(samples))
+	for round := range maxKMeansRounds {
+		changed := assign(samples, centroids, assignments)
+		if changed == 0 && round > 0 {
+			break
+		}
+
+		centroids = updateCentroids(samples, assignments, centroids)
+	}
+
+	assignments = make([]int, len(vectors))
+	assign(vectors, centroids, assignments)
+
+	return centroids, assignments
+}
+
+// seedCentroids picks initial centroids with k-means++, i.e., spread out across the vectors
+func seedCentroids(vectors [][]float32, k int, rng *rand.Rand) [][]float32 {
+	centroids := [][]float32{vectors[rng.Intn(len(vectors))]}
+	distances := make([]float64, len(vectors))
+	for len(centroids) < k {
+		var total float64
+		for i, vector := range vectors {
+			distance := 1 - float64(dot(vector, centroids[len(centroids)-1]))
+			if len(centroids) == 1 || distance < distances[i] {
+				distances[i] = max(distance, 0)
+			}
+			total += distances[i] * distances[i]
+		}
+
+		if total == 0 {
+			// Fewer distinct vectors than clusters
+			centroids = append(centroids, vectors[rng.Intn(len(vectors))])
+			continue
+		}
+
+		target := rng.Float64() * total
+		next := len(vectors) - 1
+		for i, distance := range distances {
+			target -= distance * distance
+			if target <= 0 {
+				next = i
+				break
+			}
+		}
+		centroids = append(centroids, vectors[next])
+	}
+
+	return centroids
+}
+
+// assign moves each vector to its most similar centroid, returning how many moved
+func assign(vectors, centroids [][]float32, assignments []int) int {
+	var changed int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	workers := runtime.NumCPU()
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var moved int
+			for i := worker; i < len(vectors); i += workers {
+				best, bestSimilarity := 0, float32(math.Inf(-1))
+				for c, centroid := range centroids {
+					similarity := dot(vectors[i], centroid)
+					if similarity > bestSimilarity {
+						best, bestSimilarity = c, similarity
+					}
+				}
+
+				if assignments[i] != best {
+					assignments[i] = best
+					moved++
+				}
+			}
+
+			mu.Lock()
+			changed += moved
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return changed
+}
+
+// updateCentroids recomputes centroids as the normalized mean of their vectors,
+// keeping the previous centroid of clusters that emptied
+func updateCentroids(vectors [][]float32, assignments []int, previous [][]float32) [][]float32 {
+	sums := make([][]float64, len(previous))
+	counts := make([]int, len(previous))
+	for i, vector := range vectors {
+		c := assignments[i]
+		if sums[c] == nil {
+			sums[c] = make([]float64, len(vector))
+		}
+
+		for d, value := range vector {
+			sums[c][d] += float64(value)
+		}
+		counts[c]++
+	}
+
+	centroids := make([][]float32, len(previous))
+	for c, sum := range sums {
+		if counts[c] == 0 {
+			centroids[c] = previous[c]
+			continue
+		}
+
+		var norm float64
+		for _, value := range sum {
+			norm += value * value
+		}
+		norm = math.Sqrt(norm)
+
+		centroid := make([]float32, len(sum))
+		for d, value := range sum {
+			if norm > 0 {
+				centroid[d] = float32(value / norm)
+			}
+		}
+		centroids[c] = centroid
+	}
+
+	return centroids
+}
+
+// describeTopics labels clusters with their most distinctive words (tf-idf across clusters),
+// common directories, & the chunks closest to their centroids
+func describeTopics(
+	chunks []*parser.Chunk,
+	embeddings [][]float32,
+	centroids [][]float32,
+	assignments []int,
+	representatives int,
+) []Topic {
+	members := make([][]int, len(centroids))
+	for i, c := range assignments {
+		members[c] = append(members[c], i)
+	}
+
+	wordCounts := make([]map[string]int, len(centroids))
+	clustersWithWord := map[string]int{}
+	for c, indices := range members {
+		wordCounts[c] = map[string]int{}
+		for _, i := range indices {
+			for word, count := range topicWords(chunks[i]) {
+				wordCounts[c][word] += count
+			}
+		}
+
+		for word := range wordCounts[c] {
+			clustersWithWord[word]++
+		}
+	}
+
+	var topics []Topic
+	for c, indices := range members {
+		if len(indices) == 0 {
+			continue
+		}
+
+		type scoredWord struct {
+			word  string
+			score float64
+		}
+
+		var words []scoredWord
+		for word, count := range wordCounts[c] {
+			idf := math.Log(1 + float64(len(centroids))/float64(clustersWithWord[word]))
+			words = append(words, scoredWord{word, float64(count) * idf})
+		}
+		sort.Slice(words, func(i, j int) bool {
+			if words[i].score != words[j].score {
+				return words[i].score > words[j].score
+			}
+
+			return words[i].word < words[j].word
+		})
+
+		topic := Topic{Size: len(indices)}
+		for _, word := range words[:min(len(words), topicKeywords)] {
+			topic.Keywords = append(topic.Keywords, word.word)
+		}
+		topic.Label = strings.Join(topic.Keywords[:min(len(topic.Keywords), topicLabelWords)], " / ")
+
+		dirCounts := map[string]int{}
+		for _, i := range indices {
+			dirCounts[path.Dir(chunks[i].File)]++
+		}
+
+		dirs := make([]string, 0, len(dirCounts))
+		for dir := range dirCounts {
+			dirs = append(dirs, dir)
+		}
+		sort.Slice(dirs, func(i, j int) bool {
+			if dirCounts[dirs[i]] != dirCounts[dirs[j]] {
+				return dirCounts[dirs[i]] > dirCounts[dirs[j]]
+			}
+
+			return dirs[i] < dirs[j]
+		})
+		topic.Dirs = dirs[:min(len(dirs), topicDirs)]
+
+		closest := slices.Clone(indices)
+		sort.SliceStable(closest, func(i, j int) bool {
+			return dot(embeddings[closest[i]], centroids[c]) > dot(embeddings[closest[j]], centroids[c])
+		})
+		for _, i := range closest[:min(len(closest), representatives)] {
+			topic.Representatives = append(topic.Representatives, chunks[i])
+		}
+
+		topics = append(topics, topic)
+	}
+
+	sort.SliceStable(topics, func(i, j int) bool {
+		return topics[i].Size > topics[j].Size
+	})
+
+	return topics
+}
+
+// topicWords counts the words of a chunk's identifier path & summary, names count double
+func topicWords(chunk *parser.Chunk) map[string]int {
+	counts := map[string]int{}
+	add := func(text string, weight int) {
+		for _, word := range parser.SplitIdentifier(text) {
+			if len(word) > 2 && !stopWords[word] && !codeWords[word] {
+				counts[word] += weight
+			}
+		}
+	}
+
+	if chunk.Name != "" {
+		add(chunk.Path, 2)
+	}
+	add(chunk.Summary, 1)
+
+	return counts
+}
+
+// FormatTopics renders topics, each with its representative chunks
+func FormatTopics(topics []Topic) string {
+	var b strings.Builder
+	for i, topic := range topics {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "Topic %d: %s (%d chunks in %s)\n", i+1, topic.Label, topic.Size, strings.Join(topic.Dirs, ", "))
+		fmt.Fprintf(&b, "Keywords: %s\n", strings.Join(topic.Keywords, ", "))
+		for _, chunk := range topic.Representatives {
+			fmt.Fprintf(&b, "- %s\n", FormatChunk(chunk))
+		}
+	}
+
+	return b.String()
+}
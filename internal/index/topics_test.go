@@ -0,0 +1,77 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cartSource = `package cart
+
+// AddItem adds an item to the shopping cart
+func AddItem(cart *Cart, item Item) {
+	cart.Items = append(cart.Items, item)
+}
+
+// RemoveItem removes an item from the shopping cart
+func RemoveItem(cart *Cart, item Item) {
+	cart.Items = without(cart.Items, item)
+}
+
+// CartTotal sums the prices of the items in the shopping cart
+func CartTotal(cart *Cart) float64 {
+	return sum(cart.Items)
+}
+`
+
+const authSource = `package auth
+
+// Login authenticates a user by password & starts a session
+func Login(user User, password string) Session {
+	return startSession(user, password)
+}
+
+// Logout ends the user's session
+func Logout(session Session) {
+	endSession(session)
+}
+
+// RefreshSession extends the user's session
+func RefreshSession(session Session) Session {
+	return extendSession(session)
+}
+`
+
+func TestTopics(t *testing.T) {
+	idx := newTestIndex(t, map[string]string{
+		"cart/cart.go": cartSource,
+		"auth/auth.go": authSource,
+	})
+
+	topics, err := idx.Topics(context.Background(), TopicOptions{K: 2, Representatives: 2})
+	require.NoError(t, err)
+	require.Len(t, topics, 2)
+
+	byDir := map[string]Topic{}
+	for _, topic := range topics {
+		assert.Equal(t, 3, topic.Size)
+		assert.Len(t, topic.Representatives, 2)
+		require.Len(t, topic.Dirs, 1)
+		byDir[topic.Dirs[0]] = topic
+	}
+
+	assert.Contains(t, byDir["cart"].Keywords, "cart")
+	assert.Contains(t, byDir["auth"].Keywords, "session")
+
+	scoped, err := idx.Topics(context.Background(), TopicOptions{K: 2, Dir: "auth"})
+	require.NoError(t, err)
+
+	var size int
+	for _, topic := range scoped {
+		assert.Equal(t, []string{"auth"}, topic.Dirs)
+		size += topic.Size
+	}
+	assert.Equal(t, 3, size)
+}
@@ -3,28 +3,57 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/st3v3nmw/sourcerer-mcp/internal/analyzer"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/git"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
 )
 
+// defaultMaxTokens bounds get_chunk_code responses unless overridden
+const defaultMaxTokens = 20000
+
+// defaultDuplicateClusters bounds find_duplicates responses unless overridden
+const defaultDuplicateClusters = 20
+
+// maxDuplicateCandidates bounds how many chunks find_duplicates compares pairwise
+const maxDuplicateCandidates = 2000
+
+// maxSearchQueries bounds how many queries a single semantic_search runs concurrently
+const maxSearchQueries = 8
+
+// Options configures a Server, zero values fall back to defaults
+type Options struct {
+	analyzer.Options
+	MaxTokens int // default token budget for get_chunk_code
+}
+
 type Server struct {
 	workspaceRoot string
+	maxTokens     int
 	mcp           *server.MCPServer
 	analyzer      *analyzer.Analyzer
 }
 
-func NewServer(workspaceRoot, version string) (*Server, error) {
-	a, err := analyzer.New(context.Background(), workspaceRoot)
+func NewServer(workspaceRoot, version string, opts Options) (*Server, error) {
+	a, err := analyzer.New(context.Background(), workspaceRoot, opts.Options)
 	if err != nil {
 		return nil, err
 	}
 
+	maxTokens := opts.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokens
+	}
+
 	s := &Server{
 		workspaceRoot: workspaceRoot,
+		maxTokens:     maxTokens,
 		analyzer:      a,
 	}
 
@@ -44,21 +73,32 @@ Sourcerer's semantic search understands concepts and relationships:
 - Include context about what the code should accomplish
 - Mention related functionality or typical patterns
 
+If you're unsure how the code phrases a concept, pass several phrasings in
+queries instead of making one call per phrasing; their results are fused into
+a single ranking. Set expand to also search for related identifiers from the
+workspace's symbols (e.g., "rate limiting" -> RateLimiter).
+
 The line numbers shown in search results (e.g., "lines 45-67") reference the
 exact location in the original file and can be used with standard file tools
 if you need to read or edit those specific sections.
 
+Search results also show each chunk's estimated size (e.g., "~350 tokens") so
+you can choose what to fetch. get_chunk_code serves ids in the order given
+within a token budget (max_tokens), truncating oversized chunks and listing
+the omitted ones.
+
 Use the file_types param to filter search results (defaults to ['src', 'docs']):
 - src: Source code
 - docs: Documentation
 - tests: Tests code
 
 AVOID SEMANTIC SEARCH FOR EXACT MATCHES:
-If you need to find specific names or exact text, use pattern-based tools
-like grep & glob instead:
+If you know a symbol's name but not its file, use find_symbol instead. It
+supports qualified names (e.g., AuthService::login) and exact, prefix & fuzzy
+matching. For other exact text, use pattern-based tools like grep & glob:
 
 Good: "authentication logic and session management"
-Avoid: "AuthService class definition" (use grep instead)
+Avoid: "AuthService class definition" (use find_symbol instead)
 
 CHUNK IDs
 Use chunk IDs to retrieve source code:
@@ -66,15 +106,60 @@ Use chunk IDs to retrieve source code:
 - Specific method in Type: path/to/file.ext::Type::method
 - Variable: path/to/file.ext::Var
 - Content-based chunks: file.ext::695fffd41945e08d (imports, markdown, etc)
+- Part of an oversized chunk: path/to/file.ext::Func#2 (use path/to/file.ext::Func
+  to get the whole chunk)
+- Go chunks can also be qualified by their package's name, directory, or import
+  path instead of their file: pkg::Type::method
+- Python chunks can be qualified by their module's dotted path or a package that
+  re-exports them from its __init__.py: pkg.models::User, pkg::User
+- Python module docstrings, property setters, overloads & main blocks:
+  file.py::__doc__, file.py::Type::name.setter, file.py::f.overload, file.py::__main__
+
+Go methods are often spread across a package's files, use list_methods to list
+all methods of a type.
 
 Chunk IDs are stable across minor edits but update when code structure
 changes (renames, moves, deletions). Use get_chunk_code with these precise
 ids to get exactly the code you need.
 
+When editing, get_chunk_code can also include surrounding lines, the enclosing
+chunk's signature (e.g., a method's class), the file's imports, and line
+numbers, saving a full file read. Use mode "signature" to only get the shape of
+large classes/types, i.e., an API surface view with bodies elided.
+
+If an ID doesn't exist, get_chunk_code resolves it to the chunk you most likely
+meant (marked "resolved from ...") or lists "did you mean" IDs.
+
 If you already know the specific function/class/method/struct/etc and file
 location from previous context, construct the chunk ID yourself and use
 get_chunk_code directly rather than semantic searching again.
 
+ORIENTATION:
+In an unfamiliar codebase, use get_topics to see its major areas, each with
+representative chunk IDs. Pass dir to drill down into an area.
+
+BEFORE WRITING NEW CODE:
+Pass a draft of a new function or helper to find_similar_code to check whether
+the workspace already has something similar that you can reuse or extend.
+
+TESTS:
+After changing a chunk, use find_tests with its ID to find the tests to run &
+update. find_tested_code goes the other way, from a test to the code it tests.
+
+CODE REVIEW:
+Use changed_only (uncommitted changes) or changed_since (e.g., "main") with
+semantic_search to only search chunks touched by a diff. list_changed_chunks
+maps a diff to the chunks it added, modified, or deleted.
+
+HISTORY:
+Use chunk_history to find when & why a chunk's behavior changed. It returns
+the commits that changed the chunk with their messages & chunk-scoped diffs.
+
+FRESHNESS:
+Changed files are reindexed automatically after a short delay. If you just
+edited files and need search results to reflect the edits right away, call
+reindex_files with those paths first.
+
 BATCHING:
 Batch operations instead of making separate requests which waste tokens and
 time (round-trips).
@@ -89,17 +174,78 @@ just read it directly with your standard tools.
 		mcp.NewTool("semantic_search",
 			mcp.WithDescription("Find relevant code using semantic search"),
 			mcp.WithString("query",
-				mcp.Required(),
 				mcp.Description("Your search"),
 			),
+			mcp.WithArray("queries",
+				mcp.WithStringItems(),
+				mcp.MaxItems(maxSearchQueries),
+				mcp.Description(fmt.Sprintf(
+					"Several phrasings or related searches (up to %d), run together with results fused into one ranking",
+					maxSearchQueries,
+				)),
+			),
+			mcp.WithBoolean("expand",
+				mcp.Description("Also search for related identifiers from the workspace's symbols (e.g., rate limiting -> RateLimiter)"),
+			),
 			mcp.WithArray("file_types",
 				mcp.WithStringItems(),
 				mcp.Description("Filter by file type(s)"),
 			),
+			mcp.WithString("changed_since",
+				mcp.Description("Only include chunks changed since this git ref (e.g., main, HEAD~3)"),
+			),
+			mcp.WithBoolean("changed_only",
+				mcp.Description("Only include chunks touched by uncommitted changes"),
+			),
 		),
 		s.semanticSearch,
 	)
 
+	s.mcp.AddTool(
+		mcp.NewTool("find_symbol",
+			mcp.WithDescription("Find the chunks defining a symbol by name across the workspace"),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Symbol name, optionally qualified (e.g., NewWatcher, Analyzer::chunk)"),
+			),
+			mcp.WithString("mode",
+				mcp.Enum(index.MatchExact, index.MatchPrefix, index.MatchFuzzy),
+				mcp.Description("Matching mode (defaults to the most precise mode with matches)"),
+			),
+			mcp.WithString("kind",
+				mcp.Description("Filter by chunk kind (e.g., function, method, class, type, component, hook)"),
+			),
+			mcp.WithString("language",
+				mcp.Description("Filter by language (e.g., go, python)"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of results (defaults to 20)"),
+			),
+		),
+		s.findSymbol,
+	)
+
+	s.mcp.AddTool(
+		mcp.NewTool("list_methods",
+			mcp.WithDescription("List all methods of a type across its package's files"),
+			mcp.WithString("id",
+				mcp.Required(),
+				mcp.Description("The type's chunk ID (e.g., path/to/file.go::Type) or package-qualified name (e.g., pkg::Type)"),
+			),
+		),
+		s.listMethods,
+	)
+
+	s.mcp.AddTool(
+		mcp.NewTool("list_changed_chunks",
+			mcp.WithDescription("List the chunks added, modified, or deleted by uncommitted changes or since a git ref"),
+			mcp.WithString("since",
+				mcp.Description("Git ref to diff against (defaults to uncommitted changes)"),
+			),
+		),
+		s.listChangedChunks,
+	)
+
 	s.mcp.AddTool(
 		mcp.NewTool("find_similar_chunks",
 			mcp.WithDescription("Find code chunks semantically similar to a given chunk"),
@@ -111,6 +257,89 @@ just read it directly with your standard tools.
 		s.findSimilarChunks,
 	)
 
+	s.mcp.AddTool(
+		mcp.NewTool("find_similar_code",
+			mcp.WithDescription("Find existing chunks similar to a code snippet, e.g., to reuse helpers instead of duplicating them"),
+			mcp.WithString("code",
+				mcp.Required(),
+				mcp.Description("The code to find similar chunks for, e.g., a function you're about to write"),
+			),
+			mcp.WithString("language",
+				mcp.Enum("go", "javascript", "markdown", "python", "typescript"),
+				mcp.Description("The snippet's language, improves matches (snippets in other languages are matched as-is)"),
+			),
+			mcp.WithArray("file_types",
+				mcp.WithStringItems(),
+				mcp.Description("Filter by file type(s) (defaults to ['src'])"),
+			),
+		),
+		s.findSimilarCode,
+	)
+
+	s.mcp.AddTool(
+		mcp.NewTool("find_duplicates",
+			mcp.WithDescription("Find clusters of duplicated & near-duplicated code across the workspace, e.g., to plan refactors"),
+			mcp.WithNumber("threshold",
+				mcp.Description(fmt.Sprintf("Minimum similarity of near-duplicates (defaults to %.2f)", index.DefaultDuplicateThreshold)),
+			),
+			mcp.WithBoolean("exact",
+				mcp.Description("Only report code that's identical ignoring comments, whitespace & the chunks' own names"),
+			),
+			mcp.WithString("dir",
+				mcp.Description("Only consider chunks in this directory (e.g., internal/api)"),
+			),
+			mcp.WithNumber("min_tokens",
+				mcp.Description(fmt.Sprintf("Ignore chunks smaller than this (defaults to %d)", index.DefaultDuplicateMinTokens)),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description(fmt.Sprintf("Maximum number of clusters, largest first (defaults to %d)", defaultDuplicateClusters)),
+			),
+		),
+		s.findDuplicates,
+	)
+
+	s.mcp.AddTool(
+		mcp.NewTool("find_tests",
+			mcp.WithDescription("Find the tests of a chunk, e.g., to know which tests to run & update after changing it"),
+			mcp.WithString("id",
+				mcp.Required(),
+				mcp.Description("The chunk ID to find tests for"),
+			),
+		),
+		s.findTests,
+	)
+
+	s.mcp.AddTool(
+		mcp.NewTool("find_tested_code",
+			mcp.WithDescription("Find the code a test chunk tests"),
+			mcp.WithString("id",
+				mcp.Required(),
+				mcp.Description("The test chunk ID to find the tested code for"),
+			),
+		),
+		s.findTestedCode,
+	)
+
+	s.mcp.AddTool(
+		mcp.NewTool("get_topics",
+			mcp.WithDescription("Map the major areas of the codebase by clustering its chunks into labeled topics"),
+			mcp.WithNumber("k",
+				mcp.Description("Number of topics (defaults to one based on the workspace's size)"),
+			),
+			mcp.WithString("dir",
+				mcp.Description("Only cluster chunks in this directory (e.g., internal/api), to drill down into an area"),
+			),
+			mcp.WithArray("file_types",
+				mcp.WithStringItems(),
+				mcp.Description("Filter by file type(s) (defaults to ['src'])"),
+			),
+			mcp.WithNumber("representatives",
+				mcp.Description(fmt.Sprintf("Chunks listed per topic (defaults to %d)", index.DefaultTopicRepresentatives)),
+			),
+		),
+		s.getTopics,
+	)
+
 	s.mcp.AddTool(
 		mcp.NewTool("get_chunk_code",
 			mcp.WithDescription("Get the actual code you need to examine"),
@@ -120,10 +349,56 @@ just read it directly with your standard tools.
 				mcp.Required(),
 				mcp.Description("Chunks to get code for"),
 			),
+			mcp.WithString("mode",
+				mcp.Enum(analyzer.ModeFull, analyzer.ModeSignature),
+				mcp.Description("full (default) or signature: declarations with function & method bodies elided as ..."),
+			),
+			mcp.WithNumber("context_lines",
+				mcp.Description("Number of surrounding lines to include before & after each chunk"),
+			),
+			mcp.WithBoolean("include_parent",
+				mcp.Description("Include the enclosing chunk's signature, e.g., the class of a method"),
+			),
+			mcp.WithBoolean("include_imports",
+				mcp.Description("Include the imports of each chunk's file"),
+			),
+			mcp.WithBoolean("line_numbers",
+				mcp.Description("Prefix each line with its line number"),
+			),
+			mcp.WithNumber("max_tokens",
+				mcp.Description("Estimated token budget for the response, chunks beyond it are truncated or omitted"),
+			),
 		),
 		s.getChunkCode,
 	)
 
+	s.mcp.AddTool(
+		mcp.NewTool("chunk_history",
+			mcp.WithDescription("Find the commits that introduced or changed a chunk, with diffs of just that chunk"),
+			mcp.WithString("id",
+				mcp.Required(),
+				mcp.Description("The chunk ID to get the history of"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of commits to return (defaults to 10)"),
+			),
+		),
+		s.chunkHistory,
+	)
+
+	s.mcp.AddTool(
+		mcp.NewTool("reindex_files",
+			mcp.WithDescription("Synchronously reindex specific files, e.g., ones you just edited"),
+			mcp.WithArray("paths",
+				mcp.WithStringItems(),
+				mcp.MinItems(1),
+				mcp.Required(),
+				mcp.Description("File paths relative to the workspace root"),
+			),
+		),
+		s.reindexFiles,
+	)
+
 	s.mcp.AddTool(
 		mcp.NewTool("index_workspace",
 			mcp.WithDescription("Index all pending files in the workspace"),
@@ -146,10 +421,30 @@ func (s *Server) Serve() error {
 }
 
 func (s *Server) semanticSearch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	queries := request.GetStringSlice("queries", nil)
 	query := request.GetString("query", "")
-	fileTypes := request.GetStringSlice("file_types", []string{"src", "docs"})
+	if query != "" {
+		queries = append([]string{query}, queries...)
+	}
 
-	results, err := s.analyzer.SemanticSearch(ctx, query, fileTypes)
+	if len(queries) == 0 {
+		return mcp.NewToolResultError("Provide a query or queries to search for"), nil
+	}
+
+	if len(queries) > maxSearchQueries {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Too many queries (%d), pass at most %d per search", len(queries), maxSearchQueries,
+		)), nil
+	}
+
+	opts := analyzer.SearchOptions{
+		FileTypes:    request.GetStringSlice("file_types", []string{"src", "docs"}),
+		ChangedSince: request.GetString("changed_since", ""),
+		ChangedOnly:  request.GetBool("changed_only", false),
+		Expand:       request.GetBool("expand", false),
+	}
+
+	results, err := s.analyzer.SemanticSearch(ctx, queries, opts)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
 	}
@@ -162,6 +457,83 @@ func (s *Server) semanticSearch(ctx context.Context, request mcp.CallToolRequest
 	return mcp.NewToolResultText(content), nil
 }
 
+func (s *Server) findSymbol(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query := index.SymbolQuery{
+		Name:     request.GetString("name", ""),
+		Mode:     request.GetString("mode", ""),
+		Kind:     request.GetString("kind", ""),
+		Language: request.GetString("language", ""),
+		Limit:    request.GetInt("limit", 0),
+	}
+
+	matches, err := s.analyzer.FindSymbol(ctx, query)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Symbol lookup failed: %v", err)), nil
+	}
+
+	if len(matches) == 0 {
+		return mcp.NewToolResultText("No matching symbols found."), nil
+	}
+
+	lines := make([]string, 0, len(matches))
+	for _, match := range matches {
+		chunk := match.Chunk
+		lines = append(
+			lines,
+			fmt.Sprintf(
+				"%s | %s [%s] (%s %s, %s match)",
+				chunk.ID(), chunk.Summary, chunk.Lines(), chunk.Language, chunk.Kind, match.Mode,
+			),
+		)
+	}
+
+	return mcp.NewToolResultText(strings.Join(lines, "\n")), nil
+}
+
+func (s *Server) listMethods(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	typeID := request.GetString("id", "")
+
+	typeChunk, methods, err := s.analyzer.ListMethods(ctx, typeID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list methods: %v", err)), nil
+	}
+
+	lines := []string{fmt.Sprintf("%s | %s [%s]", typeChunk.ID(), typeChunk.Summary, typeChunk.Lines())}
+	for _, method := range methods {
+		lines = append(lines, fmt.Sprintf("  %s | %s [%s]", method.ID(), method.Summary, method.Lines()))
+	}
+
+	if len(methods) == 0 {
+		lines = append(lines, "  No methods found.")
+	}
+
+	return mcp.NewToolResultText(strings.Join(lines, "\n")), nil
+}
+
+func (s *Server) listChangedChunks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	since := request.GetString("since", "")
+
+	changes, err := s.analyzer.ChangedChunks(ctx, since)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list changed chunks: %v", err)), nil
+	}
+
+	if len(changes) == 0 {
+		return mcp.NewToolResultText("No changed chunks found."), nil
+	}
+
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		chunk := change.Chunk
+		lines = append(
+			lines,
+			fmt.Sprintf("%s %s | %s [%s]", change.Status, chunk.ID(), chunk.Summary, chunk.Lines()),
+		)
+	}
+
+	return mcp.NewToolResultText(strings.Join(lines, "\n")), nil
+}
+
 func (s *Server) findSimilarChunks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	chunkID := request.GetString("id", "")
 
@@ -178,14 +550,198 @@ func (s *Server) findSimilarChunks(ctx context.Context, request mcp.CallToolRequ
 	return mcp.NewToolResultText(content), nil
 }
 
+func (s *Server) findSimilarCode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	code := request.GetString("code", "")
+	if strings.TrimSpace(code) == "" {
+		return mcp.NewToolResultError("Provide the code to find similar chunks for"), nil
+	}
+
+	language := request.GetString("language", "")
+	fileTypes := request.GetStringSlice("file_types", []string{"src"})
+
+	results, err := s.analyzer.FindSimilarCode(ctx, code, language, fileTypes)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	if len(results) == 0 {
+		return mcp.NewToolResultText("No similar chunks found."), nil
+	}
+
+	content := strings.Join(results, "\n")
+	return mcp.NewToolResultText(content), nil
+}
+
+func (s *Server) findDuplicates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	opts := index.DuplicateOptions{
+		Threshold:     float32(request.GetFloat("threshold", 0)),
+		MinTokens:     request.GetInt("min_tokens", 0),
+		Dir:           request.GetString("dir", ""),
+		Exact:         request.GetBool("exact", false),
+		MaxCandidates: maxDuplicateCandidates,
+	}
+
+	clusters, err := s.analyzer.FindDuplicates(ctx, opts)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Duplicate detection failed: %v", err)), nil
+	}
+
+	if len(clusters) == 0 {
+		return mcp.NewToolResultText("No duplicates found."), nil
+	}
+
+	limit := request.GetInt("limit", defaultDuplicateClusters)
+	if limit <= 0 {
+		limit = defaultDuplicateClusters
+	}
+
+	content := index.FormatDuplicates(clusters[:min(len(clusters), limit)])
+	if len(clusters) > limit {
+		content += fmt.Sprintf("\n%d smaller clusters omitted, raise limit to see them", len(clusters)-limit)
+	}
+
+	return mcp.NewToolResultText(content), nil
+}
+
+func (s *Server) findTests(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chunkID := request.GetString("id", "")
+
+	links, err := s.analyzer.FindTests(ctx, chunkID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	if len(links) == 0 {
+		return mcp.NewToolResultText("No tests found."), nil
+	}
+
+	return mcp.NewToolResultText(formatTestLinks(links)), nil
+}
+
+func (s *Server) findTestedCode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	testID := request.GetString("id", "")
+
+	links, err := s.analyzer.FindTestedCode(ctx, testID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	if len(links) == 0 {
+		return mcp.NewToolResultText("No tested code found."), nil
+	}
+
+	return mcp.NewToolResultText(formatTestLinks(links)), nil
+}
+
+func formatTestLinks(links []analyzer.TestLink) string {
+	lines := make([]string, 0, len(links))
+	for _, link := range links {
+		lines = append(lines, fmt.Sprintf("%s (%s)", index.FormatChunk(link.Chunk), strings.Join(link.Reasons, ", ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (s *Server) getTopics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	opts := index.TopicOptions{
+		K:               request.GetInt("k", 0),
+		Dir:             request.GetString("dir", ""),
+		FileTypes:       request.GetStringSlice("file_types", []string{"src"}),
+		Representatives: request.GetInt("representatives", 0),
+	}
+
+	topics, err := s.analyzer.Topics(ctx, opts)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Topic clustering failed: %v", err)), nil
+	}
+
+	if len(topics) == 0 {
+		return mcp.NewToolResultText("No chunks to cluster."), nil
+	}
+
+	return mcp.NewToolResultText(index.FormatTopics(topics)), nil
+}
+
 func (s *Server) getChunkCode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ids := request.GetStringSlice("ids", []string{})
+	opts := analyzer.ChunkCodeOptions{
+		Mode:           request.GetString("mode", analyzer.ModeFull),
+		ContextLines:   request.GetInt("context_lines", 0),
+		IncludeParent:  request.GetBool("include_parent", false),
+		IncludeImports: request.GetBool("include_imports", false),
+		LineNumbers:    request.GetBool("line_numbers", false),
+		MaxTokens:      request.GetInt("max_tokens", s.maxTokens),
+	}
 
-	chunks := s.analyzer.GetChunkCode(ctx, ids)
+	chunks := s.analyzer.GetChunkCode(ctx, ids, opts)
 
 	return mcp.NewToolResultText(chunks), nil
 }
 
+func (s *Server) chunkHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chunkID := request.GetString("id", "")
+	limit := request.GetInt("limit", 10)
+
+	history, err := s.analyzer.ChunkHistory(ctx, chunkID, limit)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get history: %v", err)), nil
+	}
+
+	var sb strings.Builder
+	if history.ID != chunkID {
+		fmt.Fprintf(&sb, "<history of %s, resolved from %s>\n\n", history.ID, chunkID)
+	}
+
+	truncationNote := ""
+	if history.Truncated {
+		truncationNote = "<history truncated, older commits that changed this chunk aren't shown>\n"
+	}
+
+	if len(history.Changes) == 0 {
+		sb.WriteString("No commits changed this chunk.\n" + truncationNote)
+		return mcp.NewToolResultText(sb.String()), nil
+	}
+
+	for _, change := range history.Changes {
+		fmt.Fprintf(
+			&sb,
+			"== %s | %s | %s ==\n\n%s\n\n%s\n\n",
+			git.ShortHash(change.Commit.Hash),
+			change.Commit.Author,
+			change.Commit.Date.Format(time.DateOnly),
+			change.Commit.Message,
+			change.Diff,
+		)
+	}
+	sb.WriteString(truncationNote)
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func (s *Server) reindexFiles(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	paths := request.GetStringSlice("paths", []string{})
+
+	errs := s.analyzer.ReindexFiles(ctx, paths)
+
+	filePaths := make([]string, 0, len(errs))
+	for filePath := range errs {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	lines := make([]string, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		err := errs[filePath]
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("%s: failed (%v)", filePath, err))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: reindexed", filePath))
+		}
+	}
+
+	return mcp.NewToolResultText(strings.Join(lines, "\n")), nil
+}
+
 func (s *Server) indexWorkspace(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	go s.analyzer.IndexWorkspace(ctx)
 
@@ -193,13 +749,34 @@ func (s *Server) indexWorkspace(ctx context.Context, request mcp.CallToolRequest
 }
 
 func (s *Server) getIndexStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	pendingFiles, lastIndexedAt := s.analyzer.GetIndexStatus()
+	indexStatus := s.analyzer.GetIndexStatus()
 
-	status := fmt.Sprintf("Number of pending files: %d, last indexed: ", pendingFiles)
-	if lastIndexedAt.IsZero() {
+	status := fmt.Sprintf("Number of pending files: %d, last indexed: ", indexStatus.PendingFiles)
+	if indexStatus.LastIndexedAt.IsZero() {
 		status += "in progress"
 	} else {
-		status += humanize.Time(lastIndexedAt)
+		status += humanize.Time(indexStatus.LastIndexedAt)
+	}
+
+	if indexStatus.Commit != "" {
+		status += "\nIndexed commit: " + git.ShortHash(indexStatus.Commit)
+		if indexStatus.Branch != "" {
+			status += fmt.Sprintf(" (%s)", indexStatus.Branch)
+		}
+
+		if indexStatus.HeadCommit != "" && indexStatus.HeadCommit != indexStatus.Commit {
+			status += fmt.Sprintf(", HEAD is now at %s (reindexing pending)", git.ShortHash(indexStatus.HeadCommit))
+		}
+	}
+
+	if len(indexStatus.UncommittedFiles) > 0 {
+		status += fmt.Sprintf(
+			"\nUncommitted changes indexed: %d file(s) (%s)",
+			len(indexStatus.UncommittedFiles),
+			strings.Join(indexStatus.UncommittedFiles, ", "),
+		)
+	} else if indexStatus.HeadCommit != "" {
+		status += "\nUncommitted changes indexed: none"
 	}
 
 	return mcp.NewToolResultText(status), nil
@@ -1,11 +1,17 @@
 package parser
 
 import (
+	"os"
+	"path"
+	"strings"
+	"unicode"
+
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 	tree_sitter_go "github.com/tree-sitter/tree-sitter-go/bindings/go"
 )
 
 var GoSpec = &LanguageSpec{
+	Language: "go",
 	NamedChunks: map[string]NamedChunkExtractor{
 		"function_declaration": {
 			NameQuery: `(function_declaration name: (identifier) @name)`,
@@ -31,21 +37,91 @@ var GoSpec = &LanguageSpec{
 				(type_declaration [
 					(type_spec name: (type_identifier) @name)
 					(type_alias name: (type_identifier) @name)])`,
+			MembersQuery: goMembersQuery,
+		},
+		"type_spec": {
+			NameQuery:    `(type_spec name: (type_identifier) @name)`,
+			MembersQuery: goMembersQuery,
+		},
+		"type_alias": {
+			NameQuery: `(type_alias name: (type_identifier) @name)`,
 		},
 		"var_declaration": {
-			NameQuery: `(var_declaration (var_spec name: (identifier) @name))`,
+			NameQuery: `
+				(var_declaration [
+					(var_spec . name: (identifier) @name)
+					(var_spec_list (var_spec . name: (identifier) @name))])`,
+			Name:    goName,
+			Aliases: goAliases,
+		},
+		"var_spec": {
+			NameQuery: `(var_spec . name: (identifier) @name)`,
+			Name:      goName,
+			Aliases:   goAliases,
+		},
+		"const_declaration": {
+			NameQuery: `(const_declaration (const_spec . name: (identifier) @name))`,
+			Name:      goName,
+			Aliases:   goAliases,
+		},
+		"const_spec": {
+			NameQuery: `(const_spec . name: (identifier) @name)`,
+			Name:      goName,
+			Aliases:   goAliases,
 		},
+		// Struct fields & interface methods, embedded fields are named by their type
+		"field_declaration": {
+			NameQuery: `
+				[
+					(field_declaration . name: (field_identifier) @name)
+					(field_declaration
+						!name
+						type: [
+							(type_identifier) @name
+							(pointer_type (type_identifier) @name)
+							(qualified_type name: (type_identifier) @name)
+							(generic_type type: (type_identifier) @name)
+							(pointer_type (qualified_type name: (type_identifier) @name))])]`,
+			Name:    goName,
+			Aliases: goAliases,
+		},
+		"method_elem": {
+			NameQuery: `(method_elem name: (field_identifier) @name)`,
+			Name:      goName,
+		},
+	},
+	Groups: map[string]GroupExtractor{
 		"const_declaration": {
-			NameQuery: `(const_declaration (const_spec name: (identifier) @name))`,
+			// iota enums only make sense together
+			KeepTogetherQuery: `(iota) @iota`,
+			Name:              goEnumName,
 		},
+		"var_declaration": {
+			ListTypes: []string{"var_spec_list"},
+		},
+		"type_declaration": {},
 	},
 	FoldIntoNextNode: []string{"comment"},
 	SkipTypes: []string{
 		// Imports pollute search results
 		"import_declaration",
-		// This clause also pollutes search results
+		// This clause also pollutes search results, it's recorded as the chunks' package instead
 		"package_clause",
+		// Tokens around the members of groups, structs & interfaces
+		"const", "var", "type", "interface", "(", ")", "{", "}", "\n", ";",
+		// Embedded interfaces & type constraints
+		"type_elem",
 	},
+	ImportTypes:  []string{"import_declaration"},
+	PackageQuery: `(package_clause (package_identifier) @name)`,
+	ImportPath:   goImportPath,
+	BodyQuery: `
+		[
+			(function_declaration body: (block) @body)
+			(method_declaration body: (block) @body)
+			(func_literal body: (block) @body)
+		]`,
+	ElidedBody: "{ ... }",
 	FileTypeRules: []FileTypeRule{
 		{Pattern: "**/*_test.go", Type: FileTypeTests},
 		{Pattern: "vendor/**", Type: FileTypeIgnore},
@@ -53,6 +129,233 @@ var GoSpec = &LanguageSpec{
 	},
 }
 
+// goMembersQuery captures the members of struct & interface types
+const goMembersQuery = `
+	(type_spec
+		type: [
+			(struct_type (field_declaration_list) @members)
+			(interface_type) @members])`
+
+// goName names a declaration after the first name it declares rather than via its NameQuery,
+// which also matches the declarations nested within it, e.g., the fields of an anonymous struct
+func goName(node *tree_sitter.Node, source []byte) string {
+	names := goNames(node, source)
+	if len(names) == 0 {
+		return ""
+	}
+
+	return names[0]
+}
+
+// goAliases returns the names a declaration declares besides the first, e.g., Y in X, Y int
+func goAliases(node *tree_sitter.Node, source []byte) []string {
+	names := goNames(node, source)
+	if len(names) < 2 {
+		return nil
+	}
+
+	return names[1:]
+}
+
+// goNames returns the names a spec declares, e.g., X & Y in X, Y int, or those of a declaration's
+// only spec. Embedded fields are named by their type, e.g., Stringer for fmt.Stringer.
+func goNames(node *tree_sitter.Node, source []byte) []string {
+	switch node.Kind() {
+	case "var_declaration", "const_declaration":
+		spec := goOnlySpec(node)
+		if spec == nil {
+			return nil
+		}
+
+		return goNames(spec, source)
+	case "field_declaration":
+		if node.ChildByFieldName("name") == nil {
+			name := goTypeName(node.ChildByFieldName("type"), source)
+			if name == "" {
+				return nil
+			}
+
+			return []string{name}
+		}
+	}
+
+	cursor := node.Walk()
+	defer cursor.Close()
+
+	var names []string
+	for _, name := range node.ChildrenByFieldName("name", cursor) {
+		names = append(names, name.Utf8Text(source))
+	}
+
+	return names
+}
+
+// goOnlySpec returns the spec of a var or const declaration, nil unless it has exactly one
+func goOnlySpec(declaration *tree_sitter.Node) *tree_sitter.Node {
+	var specs []*tree_sitter.Node
+	for i := uint(0); i < declaration.NamedChildCount(); i++ {
+		child := declaration.NamedChild(i)
+		switch child.Kind() {
+		case "var_spec", "const_spec":
+			specs = append(specs, child)
+		case "var_spec_list":
+			for j := uint(0); j < child.NamedChildCount(); j++ {
+				if child.NamedChild(j).Kind() == "var_spec" {
+					specs = append(specs, child.NamedChild(j))
+				}
+			}
+		}
+	}
+
+	if len(specs) != 1 {
+		return nil
+	}
+
+	return specs[0]
+}
+
+// goTypeName returns the name of a named type, e.g., Level for *Level or Stringer for fmt.Stringer
+func goTypeName(typeNode *tree_sitter.Node, source []byte) string {
+	for typeNode != nil {
+		switch typeNode.Kind() {
+		case "type_identifier":
+			return typeNode.Utf8Text(source)
+		case "pointer_type":
+			typeNode = typeNode.NamedChild(0)
+		case "qualified_type":
+			typeNode = typeNode.ChildByFieldName("name")
+		case "generic_type":
+			typeNode = typeNode.ChildByFieldName("type")
+		default:
+			return ""
+		}
+	}
+
+	return ""
+}
+
+// goEnumName names an iota enum after its constants' type or their common prefix, e.g., Colors
+// for Red Color = iota or Levels for LevelDebug = iota, falling back to its first constant
+func goEnumName(group *tree_sitter.Node, source []byte) string {
+	var names []string
+	var typeName string
+	for i := uint(0); i < group.NamedChildCount(); i++ {
+		spec := group.NamedChild(i)
+		if spec.Kind() != "const_spec" {
+			continue
+		}
+
+		name := spec.ChildByFieldName("name")
+		if name != nil {
+			names = append(names, name.Utf8Text(source))
+		}
+
+		specType := spec.ChildByFieldName("type")
+		if typeName == "" && specType != nil {
+			typeName = specType.Utf8Text(source)
+		}
+	}
+
+	if len(names) == 0 {
+		return "iota"
+	}
+
+	base := typeName
+	if base == "" {
+		base = commonWordPrefix(names)
+	}
+
+	if base == "" {
+		return names[0]
+	}
+
+	if strings.HasSuffix(base, "s") {
+		return base + "es"
+	}
+
+	return base + "s"
+}
+
+// commonWordPrefix returns the leading words that identifiers share, e.g., Level for LevelDebug & LevelInfo
+func commonWordPrefix(identifiers []string) string {
+	prefix := identifiers[0]
+	for _, identifier := range identifiers[1:] {
+		for !strings.HasPrefix(identifier, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	// Cut back to a word boundary in every identifier
+	for prefix != "" {
+		atBoundary := true
+		for _, identifier := range identifiers {
+			if len(identifier) > len(prefix) && !isWordStart(identifier, len(prefix)) {
+				atBoundary = false
+				break
+			}
+		}
+
+		if atBoundary {
+			break
+		}
+
+		prefix = prefix[:len(prefix)-1]
+	}
+
+	return strings.TrimRight(prefix, "_")
+}
+
+// isWordStart reports whether a new word starts at i in a camelCase or snake_case identifier
+func isWordStart(identifier string, i int) bool {
+	r, prev := rune(identifier[i]), rune(identifier[i-1])
+	switch {
+	case prev == '_':
+		return true
+	case unicode.IsUpper(r):
+		return unicode.IsLower(prev) || unicode.IsDigit(prev) || (i+1 < len(identifier) && unicode.IsLower(rune(identifier[i+1])))
+	}
+
+	return false
+}
+
+// goImportPath derives the import path of a file's package from the nearest go.mod,
+// e.g., example.com/app/internal/api for internal/api/server.go in module example.com/app
+func goImportPath(workspaceRoot, filePath string) string {
+	dir := path.Dir(filePath)
+	for rel := dir; ; rel = path.Dir(rel) {
+		module := goModulePath(path.Join(workspaceRoot, rel, "go.mod"))
+		if module != "" {
+			pkgDir := dir
+			if rel != "." {
+				pkgDir = strings.TrimPrefix(strings.TrimPrefix(dir, rel), "/")
+			}
+
+			return path.Join(module, pkgDir)
+		}
+
+		if rel == "." || rel == "/" {
+			return ""
+		}
+	}
+}
+
+// goModulePath reads the module path declared by a go.mod file
+func goModulePath(goModPath string) string {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+
+	return ""
+}
+
 func NewGoParser(workspaceRoot string) (*Parser, error) {
 	parser := tree_sitter.NewParser()
 	parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_go.Language()))
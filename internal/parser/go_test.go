@@ -1,6 +1,8 @@
 package parser_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
@@ -33,7 +35,7 @@ func (s *GoParserTestSuite) TestFunctionParsing() {
 		{
 			name:      "Package Comments Hashing",
 			path:      "f2bcc925c6085e27",
-			summary:   "// Function tests",
+			summary:   "Function tests",
 			source:    `// Function tests`,
 			startLine: 1,
 			endLine:   1,
@@ -41,7 +43,7 @@ func (s *GoParserTestSuite) TestFunctionParsing() {
 		{
 			name:    "Simple Function",
 			path:    "SimpleFunction",
-			summary: "func SimpleFunction(x int) string {",
+			summary: "SimpleFunction demonstrates basic function parsing",
 			source: `// SimpleFunction demonstrates basic function parsing
 func SimpleFunction(x int) string {
 	return fmt.Sprintf("%d", x)
@@ -52,7 +54,7 @@ func SimpleFunction(x int) string {
 		{
 			name:    "Multiple Params Function",
 			path:    "MultipleParams",
-			summary: "func MultipleParams(a string, b int, c bool) (string, error) {",
+			summary: "MultipleParams shows function with multiple parameters and return values",
 			source: `// MultipleParams shows function with multiple parameters and return values
 func MultipleParams(a string, b int, c bool) (string, error) {
 	if c {
@@ -67,7 +69,7 @@ func MultipleParams(a string, b int, c bool) (string, error) {
 		{
 			name:    "No Params Function",
 			path:    "NoParams",
-			summary: "func NoParams() {",
+			summary: "NoParams function with no parameters",
 			source: `// NoParams function with no parameters
 func NoParams() {
 	fmt.Println("no params")
@@ -78,7 +80,7 @@ func NoParams() {
 		{
 			name:    "No Return Function",
 			path:    "NoReturn",
-			summary: "func NoReturn(x int) {",
+			summary: "NoReturn function with no return values",
 			source: `// NoReturn function with no return values
 func NoReturn(x int) {
 	fmt.Printf("got %d\n", x)
@@ -89,7 +91,7 @@ func NoReturn(x int) {
 		{
 			name:    "Empty Function",
 			path:    "EmptyFunction",
-			summary: "func EmptyFunction() {}",
+			summary: "EmptyFunction with empty body",
 			source: `// EmptyFunction with empty body
 func EmptyFunction() {}`,
 			startLine: 43,
@@ -98,7 +100,7 @@ func EmptyFunction() {}`,
 		{
 			name:    "Complex Signature",
 			path:    "ComplexSignature",
-			summary: "func ComplexSignature(ctx context.Context, data map[string]interface{}, opts ...func(*Config))...",
+			summary: "ComplexSignature with various parameter types",
 			source: `// ComplexSignature with various parameter types
 func ComplexSignature(ctx context.Context, data map[string]interface{}, opts ...func(*Config)) (*Result, error) {
 	return &Result{Success: true}, nil
@@ -109,7 +111,7 @@ func ComplexSignature(ctx context.Context, data map[string]interface{}, opts ...
 		{
 			name:    "Variadic Function",
 			path:    "VariadicFunction",
-			summary: "func VariadicFunction(first string, others ...int) int {",
+			summary: "VariadicFunction with variadic parameters",
 			source: `// VariadicFunction with variadic parameters
 func VariadicFunction(first string, others ...int) int {
 	return len(others)
@@ -120,7 +122,7 @@ func VariadicFunction(first string, others ...int) int {
 		{
 			name:    "Generic Function",
 			path:    "GenericFunction",
-			summary: "func GenericFunction[T any](items []T) T {",
+			summary: "GenericFunction with type parameters",
 			source: `// GenericFunction with type parameters
 func GenericFunction[T any](items []T) T {
 	var zero T
@@ -136,7 +138,7 @@ func GenericFunction[T any](items []T) T {
 		{
 			name:    "Duplicate Name Function",
 			path:    "DuplicateNameFunction",
-			summary: "func DuplicateNameFunction() string {",
+			summary: "DuplicateNameFunction - testing duplicate function names",
 			source: `// DuplicateNameFunction - testing duplicate function names
 
 func DuplicateNameFunction() string {
@@ -148,7 +150,7 @@ func DuplicateNameFunction() string {
 		{
 			name:    "Duplicate Name Function - 2",
 			path:    "DuplicateNameFunction-2",
-			summary: "func DuplicateNameFunction() string {",
+			summary: "DuplicateNameFunction (2) Testing duplicate function names",
 			source: `// DuplicateNameFunction (2)
 // Testing duplicate function names
 func DuplicateNameFunction() string {
@@ -160,7 +162,7 @@ func DuplicateNameFunction() string {
 		{
 			name:    "Duplicate Name Function - 3",
 			path:    "DuplicateNameFunction-3",
-			summary: "func DuplicateNameFunction() string {",
+			summary: "DuplicateNameFunction (3) Testing duplicate function names",
 			source: `// DuplicateNameFunction (3)
 //
 // Testing duplicate function names
@@ -173,7 +175,7 @@ func DuplicateNameFunction() string {
 		{
 			name:      "Standalone Comment Hashing",
 			path:      "d5d69632b4d3fba5",
-			summary:   "// A standalone comment",
+			summary:   "A standalone comment",
 			source:    `// A standalone comment`,
 			startLine: 85,
 			endLine:   85,
@@ -211,7 +213,7 @@ func (s *GoParserTestSuite) TestMethodParsing() {
 		{
 			name:    "Value Receiver Method",
 			path:    "User::GetName",
-			summary: "func (u User) GetName() string {",
+			summary: "GetName is a value receiver method",
 			source: `// GetName is a value receiver method
 func (u User) GetName() string {
 	return u.Name
@@ -222,7 +224,7 @@ func (u User) GetName() string {
 		{
 			name:    "Pointer Receiver Method",
 			path:    "User::SetName",
-			summary: "func (u *User) SetName(name string) {",
+			summary: "SetName is a pointer receiver method",
 			source: `// SetName is a pointer receiver method
 func (u *User) SetName(name string) {
 	u.Name = name
@@ -233,7 +235,7 @@ func (u *User) SetName(name string) {
 		{
 			name:    "Service Add User Method",
 			path:    "Service::AddUser",
-			summary: "func (s *Service) AddUser(user User) {",
+			summary: "AddUser adds a user to the service",
 			source: `// AddUser adds a user to the service
 func (s *Service) AddUser(user User) {
 	s.users = append(s.users, user)
@@ -244,7 +246,7 @@ func (s *Service) AddUser(user User) {
 		{
 			name:    "Service Find User Method",
 			path:    "Service::FindUser",
-			summary: "func (s *Service) FindUser(id int) *User {",
+			summary: "FindUser finds a user by ID",
 			source: `// FindUser finds a user by ID
 func (s *Service) FindUser(id int) *User {
 	for i := range s.users {
@@ -261,7 +263,7 @@ func (s *Service) FindUser(id int) *User {
 		{
 			name:    "Service Count Method",
 			path:    "Service::Count",
-			summary: "func (s Service) Count() int {",
+			summary: "Count returns the number of users",
 			source: `// Count returns the number of users
 func (s Service) Count() int {
 	return len(s.users)
@@ -272,7 +274,7 @@ func (s Service) Count() int {
 		{
 			name:    "Generic Pointer Receiver Method",
 			path:    "Repository::Add",
-			summary: "func (r *Repository[T]) Add(item T) {",
+			summary: "Add adds an item to the repository",
 			source: `// Add adds an item to the repository
 func (r *Repository[T]) Add(item T) {
 	r.items = append(r.items, item)
@@ -283,7 +285,7 @@ func (r *Repository[T]) Add(item T) {
 		{
 			name:    "Generic Value Receiver Method",
 			path:    "Repository::Get",
-			summary: "func (r Repository[T]) Get(index int) T {",
+			summary: "Get retrieves an item by index",
 			source: `// Get retrieves an item by index
 func (r Repository[T]) Get(index int) T {
 	return r.items[index]
@@ -294,7 +296,7 @@ func (r Repository[T]) Get(index int) T {
 		{
 			name:    "Generic Size Method",
 			path:    "Repository::Size",
-			summary: "func (r Repository[T]) Size() int {",
+			summary: "Size returns the number of items",
 			source: `// Size returns the number of items
 func (r Repository[T]) Size() int {
 	return len(r.items)
@@ -305,7 +307,7 @@ func (r Repository[T]) Size() int {
 		{
 			name:    "Service A Helper Method",
 			path:    "ServiceA::Helper",
-			summary: "func (s ServiceA) Helper() string {",
+			summary: "helper: func (s ServiceA) Helper() string",
 			source: `func (s ServiceA) Helper() string {
 	return "service A helper"
 }`,
@@ -315,7 +317,7 @@ func (r Repository[T]) Size() int {
 		{
 			name:    "Service B Helper Method",
 			path:    "ServiceB::Helper",
-			summary: "func (s ServiceB) Helper() string {",
+			summary: "helper: func (s ServiceB) Helper() string",
 			source: `func (s ServiceB) Helper() string {
 	return "service B helper"
 }`,
@@ -355,7 +357,7 @@ func (s *GoParserTestSuite) TestTypeParsing() {
 		{
 			name:    "Basic Struct",
 			path:    "BasicStruct",
-			summary: "type BasicStruct struct {",
+			summary: "BasicStruct demonstrates struct type parsing",
 			source: `// BasicStruct demonstrates struct type parsing
 type BasicStruct struct {
 	Field1 string
@@ -367,7 +369,7 @@ type BasicStruct struct {
 		{
 			name:    "Empty Struct",
 			path:    "EmptyStruct",
-			summary: "type EmptyStruct struct{}",
+			summary: "EmptyStruct demonstrates empty struct",
 			source: `// EmptyStruct demonstrates empty struct
 type EmptyStruct struct{}`,
 			startLine: 9,
@@ -376,7 +378,7 @@ type EmptyStruct struct{}`,
 		{
 			name:    "Embedded Struct",
 			path:    "EmbeddedStruct",
-			summary: "type EmbeddedStruct struct {",
+			summary: "EmbeddedStruct demonstrates struct with embedded fields",
 			source: `// EmbeddedStruct demonstrates struct with embedded fields
 type EmbeddedStruct struct {
 	BasicStruct
@@ -388,7 +390,7 @@ type EmbeddedStruct struct {
 		{
 			name:    "Simple Interface",
 			path:    "SimpleInterface",
-			summary: "type SimpleInterface interface {",
+			summary: "SimpleInterface demonstrates interface parsing",
 			source: `// SimpleInterface demonstrates interface parsing
 type SimpleInterface interface {
 	Method1() string
@@ -400,7 +402,7 @@ type SimpleInterface interface {
 		{
 			name:    "Empty Interface",
 			path:    "EmptyInterface",
-			summary: "type EmptyInterface interface{}",
+			summary: "EmptyInterface demonstrates empty interface",
 			source: `// EmptyInterface demonstrates empty interface
 type EmptyInterface interface{}`,
 			startLine: 24,
@@ -409,7 +411,7 @@ type EmptyInterface interface{}`,
 		{
 			name:    "Embedded Interface",
 			path:    "EmbeddedInterface",
-			summary: "type EmbeddedInterface interface {",
+			summary: "EmbeddedInterface demonstrates interface composition",
 			source: `// EmbeddedInterface demonstrates interface composition
 type EmbeddedInterface interface {
 	SimpleInterface
@@ -421,7 +423,7 @@ type EmbeddedInterface interface {
 		{
 			name:    "Generic Type",
 			path:    "GenericType",
-			summary: "type GenericType[T any] struct {",
+			summary: "GenericType demonstrates generic type declaration",
 			source: `// GenericType demonstrates generic type declaration
 type GenericType[T any] struct {
 	Value T
@@ -432,7 +434,7 @@ type GenericType[T any] struct {
 		{
 			name:    "Constrained Generic",
 			path:    "ConstrainedGeneric",
-			summary: "type ConstrainedGeneric[T comparable] struct {",
+			summary: "ConstrainedGeneric demonstrates generic with constraints",
 			source: `// ConstrainedGeneric demonstrates generic with constraints
 type ConstrainedGeneric[T comparable] struct {
 	Key   T
@@ -444,7 +446,7 @@ type ConstrainedGeneric[T comparable] struct {
 		{
 			name:    "Multiple Generics",
 			path:    "MultipleGenerics",
-			summary: "type MultipleGenerics[K comparable, V any] map[K]V",
+			summary: "MultipleGenerics demonstrates multiple type parameters",
 			source: `// MultipleGenerics demonstrates multiple type parameters
 type MultipleGenerics[K comparable, V any] map[K]V`,
 			startLine: 44,
@@ -453,7 +455,7 @@ type MultipleGenerics[K comparable, V any] map[K]V`,
 		{
 			name:    "Type Alias",
 			path:    "TypeAlias",
-			summary: "type TypeAlias = string",
+			summary: "TypeAlias demonstrates type alias",
 			source: `// TypeAlias demonstrates type alias
 type TypeAlias = string`,
 			startLine: 47,
@@ -462,50 +464,40 @@ type TypeAlias = string`,
 		{
 			name:    "Custom Type",
 			path:    "CustomType",
-			summary: "type CustomType string",
+			summary: "CustomType demonstrates custom type based on existing type",
 			source: `// CustomType demonstrates custom type based on existing type
 type CustomType string`,
 			startLine: 50,
 			endLine:   51,
 		},
 		{
-			name:    "Consts Block Hashing",
-			path:    "796012d7ee1311f0",
-			summary: "const (",
-			source: `// Constants for testing const parsing
-const (
-	StatusActive   = "active"
-	StatusInactive = "inactive"
-	MaxRetries     = 5
-)`,
-			startLine: 53,
-			endLine:   58,
+			name:      "Grouped Constant",
+			path:      "StatusActive",
+			summary:   `status active: StatusActive   = "active"`,
+			source:    `StatusActive   = "active"`,
+			startLine: 55,
+			endLine:   55,
 		}, {
 			name:    "Single Constant",
 			path:    "DefaultTimeout",
-			summary: "const DefaultTimeout = 30",
+			summary: "Single constant",
 			source: `// Single constant
 const DefaultTimeout = 30`,
 			startLine: 60,
 			endLine:   61,
 		},
 		{
-			name:    "Vars Block Hashing",
-			path:    "3a12064c73be460c",
-			summary: "var (",
-			source: `// Variables for testing var parsing
-var (
-	GlobalCounter int
-	SystemReady   bool = true
-	ConfigPath    string
-)`,
-			startLine: 63,
-			endLine:   68,
+			name:      "Grouped Variable",
+			path:      "SystemReady",
+			summary:   "system ready: SystemReady   bool = true",
+			source:    `SystemReady   bool = true`,
+			startLine: 66,
+			endLine:   66,
 		},
 		{
 			name:    "Another Multi Var Declaration",
-			path:    "b9303a3de4b66c8b",
-			summary: "var x, y string",
+			path:    "x",
+			summary: "Another multi var declaration",
 			source: `// Another multi var declaration
 var x, y string`,
 			startLine: 70,
@@ -514,7 +506,7 @@ var x, y string`,
 		{
 			name:    "Single Variable",
 			path:    "DefaultConfig",
-			summary: "var DefaultConfig = BasicStruct{",
+			summary: "Single variable",
 			source: `// Single variable
 var DefaultConfig = BasicStruct{
 	Field1: "default",
@@ -542,6 +534,170 @@ var DefaultConfig = BasicStruct{
 	}
 }
 
+func (s *GoParserTestSuite) TestGroupParsing() {
+	file, err := s.parser.Chunk("go/groups.go")
+	s.Require().NoError(err)
+
+	var paths []string
+	chunks := map[string]*parser.Chunk{}
+	for _, chunk := range file.Chunks {
+		if chunk.Name != "" {
+			paths = append(paths, chunk.Path)
+			chunks[chunk.Path] = chunk
+		}
+	}
+
+	s.Equal([]string{
+		"Level",
+		"Levels",
+		"Modes",
+		"MaxRequests",
+		"MaxRetries",
+		"defaultName",
+		"ready",
+		"Point",
+		"Point::X",
+		"Point::Label",
+		"Point::Stringer",
+		"Point::Level",
+		"Point::Meta",
+		"Shape",
+		"Shape::Area",
+		"Shape::Perimeter",
+		"Alias",
+		"Number",
+		"originX",
+		"handler",
+	}, paths)
+
+	tests := []struct {
+		name      string
+		path      string
+		kind      string
+		summary   string
+		source    string
+		parent    string
+		aliases   []string
+		startLine uint
+		endLine   uint
+	}{
+		{
+			name:    "Iota Enum",
+			path:    "Levels",
+			kind:    "const_declaration",
+			summary: "Logging levels",
+			source: `// Logging levels
+const (
+	// LevelDebug is for verbose output
+	LevelDebug Level = iota
+	LevelInfo
+	LevelError
+)`,
+			startLine: 8,
+			endLine:   14,
+		},
+		{
+			name:    "Grouped Constant",
+			path:    "MaxRequests",
+			kind:    "const_spec",
+			summary: "MaxRequests caps concurrent requests",
+			source: `// MaxRequests caps concurrent requests
+	MaxRequests = 10`,
+			startLine: 23,
+			endLine:   24,
+		},
+		{
+			name:      "Grouped Variable",
+			path:      "defaultName",
+			kind:      "var_spec",
+			summary:   `default name: defaultName = "anonymous"`,
+			source:    `defaultName = "anonymous"`,
+			startLine: 29,
+			endLine:   29,
+		},
+		{
+			name:    "Struct Field",
+			path:    "Point::X",
+			kind:    "field_declaration",
+			summary: "X is the horizontal position",
+			source: `// X is the horizontal position
+		X, Y  int`,
+			parent:    "Point struct {",
+			aliases:   []string{"Y"},
+			startLine: 37,
+			endLine:   38,
+		},
+		{
+			name:      "Embedded Field",
+			path:      "Point::Level",
+			kind:      "field_declaration",
+			summary:   "level: *Level",
+			source:    `*Level`,
+			parent:    "Point struct {",
+			startLine: 41,
+			endLine:   41,
+		},
+		{
+			name:    "Anonymous Struct Field",
+			path:    "Point::Meta",
+			kind:    "field_declaration",
+			summary: "meta: Meta struct",
+			source: `Meta struct {
+			Tags []string
+		}`,
+			parent:    "Point struct {",
+			startLine: 42,
+			endLine:   44,
+		},
+		{
+			name:    "Interface Method",
+			path:    "Shape::Area",
+			kind:    "method_elem",
+			summary: "Area computes the shape's area",
+			source: `// Area computes the shape's area
+		Area() float64`,
+			parent:    "Shape interface {",
+			startLine: 49,
+			endLine:   50,
+		},
+		{
+			name:      "Grouped Alias",
+			path:      "Alias",
+			kind:      "type_alias",
+			summary:   "alias: Alias = Point",
+			source:    `Alias = Point`,
+			startLine: 55,
+			endLine:   55,
+		},
+		{
+			name:    "Multi-Name Variable",
+			path:    "originX",
+			kind:    "var_declaration",
+			summary: "Origin of the grid",
+			source: `// Origin of the grid
+var originX, originY = 0, 0`,
+			aliases:   []string{"originY"},
+			startLine: 63,
+			endLine:   64,
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			chunk, exists := chunks[test.path]
+			s.Require().True(exists, "chunk %s not found", test.path)
+
+			s.Equal(test.kind, chunk.Kind)
+			s.Equal(test.summary, chunk.Summary)
+			s.Equal(test.source, chunk.Source)
+			s.Equal(test.parent, chunk.ParentSignature)
+			s.Equal(test.aliases, chunk.Aliases)
+			s.Equal(test.startLine, chunk.StartLine)
+			s.Equal(test.endLine, chunk.EndLine)
+		})
+	}
+}
+
 func (s *GoParserTestSuite) TestTestFileParsing() {
 	chunks := s.getChunks("go/tests_test.go")
 
@@ -551,7 +707,7 @@ func (s *GoParserTestSuite) TestTestFileParsing() {
 
 	s.Equal("tests", chunk.Type)
 	s.Equal("TestSimple", chunk.Path)
-	s.Equal("func TestSimple(t *testing.T) {", chunk.Summary)
+	s.Equal("TestSimple is a basic test function", chunk.Summary)
 	s.Equal(`// TestSimple is a basic test function
 func TestSimple(t *testing.T) {
 	if 1+1 != 2 {
@@ -563,6 +719,313 @@ func TestSimple(t *testing.T) {
 	s.Equal("go/tests_test.go::TestSimple", chunk.ID())
 }
 
+func (s *GoParserTestSuite) TestChunkMetadata() {
+	tests := []struct {
+		name   string
+		file   string
+		path   string
+		symbol string
+		kind   string
+	}{
+		{
+			name:   "Function",
+			file:   "go/functions.go",
+			path:   "SimpleFunction",
+			symbol: "SimpleFunction",
+			kind:   "function_declaration",
+		},
+		{
+			name:   "Method",
+			file:   "go/methods.go",
+			path:   "User::GetName",
+			symbol: "GetName",
+			kind:   "method_declaration",
+		},
+		{
+			name:   "Content-Hashed Comment",
+			file:   "go/functions.go",
+			path:   "d5d69632b4d3fba5",
+			symbol: "",
+			kind:   "comment",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			chunks := s.getChunks(test.file)
+
+			chunk, exists := chunks[test.path]
+			s.Require().True(exists, "chunk %s not found", test.path)
+
+			s.Equal(test.symbol, chunk.Name)
+			s.Equal(test.kind, chunk.Kind)
+			s.Equal("go", chunk.Language)
+		})
+	}
+}
+
+func (s *GoParserTestSuite) TestPackage() {
+	// testdata isn't a module, so there's no import path
+	chunks := s.getChunks("go/methods.go")
+	chunk, exists := chunks["User::GetName"]
+	s.Require().True(exists, "chunk %s not found", "User::GetName")
+	s.Equal("testdata", chunk.Package)
+	s.Equal("", chunk.ImportPath)
+
+	root := s.T().TempDir()
+	files := map[string]string{
+		"go.mod":                 "module example.com/app\n\ngo 1.24\n",
+		"main.go":                "package main\n\nfunc main() {}\n",
+		"internal/api/server.go": "package api\n\nfunc Serve() {}\n",
+		"tools/go.mod":           "module \"example.com/tools\"\n",
+		"tools/lint/lint.go":     "package lint\n\nfunc Run() {}\n",
+	}
+	for filePath, source := range files {
+		fullPath := filepath.Join(root, filePath)
+		s.Require().NoError(os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		s.Require().NoError(os.WriteFile(fullPath, []byte(source), 0o644))
+	}
+
+	p, err := parser.NewGoParser(root)
+	s.Require().NoError(err)
+	defer p.Close()
+
+	tests := []struct {
+		file       string
+		pkg        string
+		importPath string
+	}{
+		{file: "main.go", pkg: "main", importPath: "example.com/app"},
+		{file: "internal/api/server.go", pkg: "api", importPath: "example.com/app/internal/api"},
+		{file: "tools/lint/lint.go", pkg: "lint", importPath: "example.com/tools/lint"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.file, func() {
+			file, err := p.Chunk(test.file)
+			s.Require().NoError(err)
+			s.Require().NotEmpty(file.Chunks)
+
+			s.Equal(test.pkg, file.Package)
+			s.Equal(test.importPath, file.ImportPath)
+			for _, chunk := range file.Chunks {
+				s.Equal(test.pkg, chunk.Package)
+				s.Equal(test.importPath, chunk.ImportPath)
+			}
+		})
+	}
+}
+
+func (s *GoParserTestSuite) TestSignatures() {
+	chunks := s.getChunks("go/methods.go")
+
+	chunk, exists := chunks["User::GetName"]
+	s.Require().True(exists, "chunk %s not found", "User::GetName")
+
+	// Folded doc comments aren't part of the signature
+	s.Equal("func (u User) GetName() string {", chunk.Signature)
+}
+
+func (s *GoParserTestSuite) TestEmbeddingText() {
+	chunks := s.getChunks("go/methods.go")
+
+	chunk, exists := chunks["User::GetName"]
+	s.Require().True(exists, "chunk %s not found", "User::GetName")
+
+	s.Equal("GetName is a value receiver method", chunk.Doc)
+	s.Equal("type User struct {", chunk.ParentSignature)
+	s.Equal(`file: go/methods.go
+language: go
+chunk: User::GetName
+parent: type User struct {
+doc: GetName is a value receiver method
+
+// GetName is a value receiver method
+func (u User) GetName() string {
+	return u.Name
+}`, chunk.EmbeddingText)
+}
+
+func (s *GoParserTestSuite) TestSnippetEmbeddingText() {
+	tests := []struct {
+		name     string
+		snippet  string
+		expected string
+	}{
+		{
+			name: "Single Declaration",
+			snippet: `// Double doubles x
+func Double(x int) int {
+	return 2 * x
+}`,
+			expected: `file: 
+language: go
+chunk: Double
+doc: Double doubles x
+
+// Double doubles x
+func Double(x int) int {
+	return 2 * x
+}`,
+		},
+		{
+			name: "Method",
+			snippet: `func (s *Service) LookupUser(id int) *User {
+	return nil
+}`,
+			expected: `file: 
+language: go
+chunk: Service::LookupUser
+
+func (s *Service) LookupUser(id int) *User {
+	return nil
+}`,
+		},
+		{
+			name: "Multiple Declarations",
+			snippet: `func Double(x int) int { return 2 * x }
+
+func Triple(x int) int { return 3 * x }`,
+			expected: `file: 
+language: go
+chunk: 
+
+func Double(x int) int { return 2 * x }
+
+func Triple(x int) int { return 3 * x }`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expected, s.parser.SnippetEmbeddingText(tt.snippet))
+		})
+	}
+}
+
+func (s *GoParserTestSuite) TestImports() {
+	file, err := s.parser.Chunk("go/functions.go")
+	s.Require().NoError(err)
+
+	s.Equal(`import (
+	"context"
+	"fmt"
+)`, s.parser.Imports(file))
+}
+
+func (s *GoParserTestSuite) TestSkeletons() {
+	file, err := s.parser.Chunk("go/methods.go")
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name     string
+		path     string
+		skeleton string
+	}{
+		{
+			name: "Struct Fields Are Kept",
+			path: "User",
+			skeleton: `// User struct for testing method parsing
+type User struct {
+	ID   int
+	Name string
+}`,
+		},
+		{
+			name: "Method Body Is Elided",
+			path: "User::GetName",
+			skeleton: `// GetName is a value receiver method
+func (u User) GetName() string { ... }`,
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			skeleton, err := s.parser.Skeleton(file, test.path)
+			s.Require().NoError(err)
+			s.Equal(test.skeleton, skeleton)
+		})
+	}
+
+	_, err = s.parser.Skeleton(file, "Missing")
+	s.Error(err)
+}
+
+func (s *GoParserTestSuite) TestChunkSplitting() {
+	s.parser.SetMaxChunkTokens(15)
+	defer s.parser.SetMaxChunkTokens(0)
+
+	chunks := s.getChunks("go/functions.go")
+
+	chunk, exists := chunks["MultipleParams"]
+	s.Require().True(exists, "chunk %s not found", "MultipleParams")
+
+	// The parent keeps the whole function
+	s.Equal(24, int(chunk.StartLine))
+	s.Equal(31, int(chunk.EndLine))
+
+	tests := []struct {
+		path      string
+		source    string
+		startLine int
+		endLine   int
+	}{
+		{
+			path: "MultipleParams#1",
+			source: `// MultipleParams shows function with multiple parameters and return values
+func MultipleParams(a string, b int, c bool) (string, error) {
+	if c {
+		return fmt.Sprintf("%s-%d", a, b), nil
+	}`,
+			startLine: 24,
+			endLine:   28,
+		},
+		{
+			path: "MultipleParams#2",
+			source: `return "", fmt.Errorf("invalid")
+}`,
+			startLine: 30,
+			endLine:   31,
+		},
+	}
+
+	s.Require().Len(chunk.Parts, len(tests))
+	for i, test := range tests {
+		s.Run(test.path, func() {
+			part := chunk.Parts[i]
+			s.Equal(test.path, part.Path)
+			s.Equal(test.source, part.Source)
+			s.Equal(test.startLine, int(part.StartLine))
+			s.Equal(test.endLine, int(part.EndLine))
+			s.Equal("go/functions.go::"+test.path, part.ID())
+		})
+	}
+
+	// Small chunks aren't split
+	s.Empty(chunks["f2bcc925c6085e27"].Parts)
+}
+
+func (s *GoParserTestSuite) TestCodeWithoutComments() {
+	file, err := s.parser.ChunkSource("store.go", []byte(`package store
+
+// Set stores v at p
+func Set(p *int, v int) {
+	/* deref */ *p = v // trailing
+	url := "http://example.com" // the string's // isn't a comment
+	_ = url
+}
+`))
+	s.Require().NoError(err)
+	s.Require().Len(file.Chunks, 1)
+
+	code := file.Chunks[0].Code
+	for _, comment := range []string{"Set stores", "deref", "trailing", "isn't a comment"} {
+		s.NotContains(code, comment)
+	}
+	s.Contains(code, "*p = v")
+	s.Contains(code, `url := "http://example.com"`)
+}
+
 func TestGoParserTestSuite(t *testing.T) {
 	suite.Run(t, new(GoParserTestSuite))
 }
@@ -1,32 +1,60 @@
 package parser
 
 import (
+	"strings"
+	"unicode"
+
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 	tree_sitter_javascript "github.com/tree-sitter/tree-sitter-javascript/bindings/go"
 )
 
 var JavaScriptSpec = &LanguageSpec{
+	Language: "javascript",
 	NamedChunks: map[string]NamedChunkExtractor{
 		"function_declaration": {
 			NameQuery: `(function_declaration name: (identifier) @name)`,
+			Name:      jsName,
+			Kind:      jsReactKind,
 		},
 		"generator_function_declaration": {
 			NameQuery: `(generator_function_declaration name: (identifier) @name)`,
+			Name:      jsName,
 		},
 		"class_declaration": {
 			NameQuery: `(class_declaration name: (identifier) @name)`,
+			Name:      jsName,
+			Kind:      jsReactKind,
 		},
 		"lexical_declaration": {
-			NameQuery: `(lexical_declaration (variable_declarator name: (identifier) @name))`,
+			NameQuery:    `(lexical_declaration (variable_declarator name: (identifier) @name))`,
+			Name:         jsName,
+			MembersQuery: `(lexical_declaration (variable_declarator value: ` + jsMethodsObject + `))`,
+			Kind:         jsReactKind,
 		},
 		"variable_declaration": {
-			NameQuery: `(variable_declaration (variable_declarator name: (identifier) @name))`,
+			NameQuery:    `(variable_declaration (variable_declarator name: (identifier) @name))`,
+			Name:         jsName,
+			MembersQuery: `(variable_declaration (variable_declarator value: ` + jsMethodsObject + `))`,
+			Kind:         jsReactKind,
+		},
+		// CommonJS exports, e.g., module.exports = { ... } & exports.foo = function () {}
+		"expression_statement": {
+			Name:         jsCommonJSName,
+			MembersQuery: `(expression_statement (assignment_expression right: ` + jsMethodsObject + `))`,
+		},
+		// Object literal members, e.g., getUser: async (id) => { ... }
+		"pair": {
+			NameQuery: `(pair key: [(property_identifier) @name (string (string_fragment) @name)])`,
+			Name:      jsName,
+			Skip:      jsNonFunctionPair,
 		},
 		"method_definition": {
 			NameQuery: `(method_definition name: (property_identifier) @name)`,
+			Name:      jsName,
 		},
 		"field_definition": {
 			NameQuery: `(field_definition property: (property_identifier) @name)`,
+			Name:      jsName,
 		},
 	},
 	ExtractChildrenIn: []string{
@@ -39,16 +67,29 @@ var JavaScriptSpec = &LanguageSpec{
 		// Imports pollute search results
 		"import_statement",
 		// Skip punctuation and keyword tokens
-		"{", "}", ";",
+		"{", "}", ";", ",",
 		"class", "extends", "implements",
 		// Skip identifier tokens (they're part of declarations)
 		"identifier",
+		// Skip object literal members that only reference other declarations
+		"shorthand_property_identifier", "spread_element",
 		// Skip class heritage clauses
 		"class_heritage",
 		// Skip container nodes (but still extract their children)
 		"class_body",
 		"export_statement",
 	},
+	ImportTypes: []string{"import_statement"},
+	BodyQuery: `
+		[
+			(function_declaration body: (statement_block) @body)
+			(generator_function_declaration body: (statement_block) @body)
+			(function_expression body: (statement_block) @body)
+			(generator_function body: (statement_block) @body)
+			(arrow_function body: (statement_block) @body)
+			(method_definition body: (statement_block) @body)
+		]`,
+	ElidedBody: "{ ... }",
 	FileTypeRules: []FileTypeRule{
 		{Pattern: "**/*.test.js", Type: FileTypeTests},
 		{Pattern: "**/*.test.jsx", Type: FileTypeTests},
@@ -60,6 +101,228 @@ var JavaScriptSpec = &LanguageSpec{
 	},
 }
 
+// jsMethodsObject matches object literals with methods, e.g., const api = { getUser() { ... } },
+// capturing them as @members so that their methods are chunked, e.g., api::getUser
+const jsMethodsObject = `(object [
+	(method_definition)
+	(pair value: [(arrow_function) (function_expression) (generator_function)])
+]) @members`
+
+// jsNonFunctionPair leaves object properties that aren't functions, e.g., baseURL: "/users",
+// to the object's chunk
+func jsNonFunctionPair(node *tree_sitter.Node, source []byte) bool {
+	value := node.ChildByFieldName("value")
+	if value == nil {
+		return true
+	}
+
+	switch value.Kind() {
+	case "arrow_function", "function_expression", "generator_function":
+		return false
+	}
+
+	return true
+}
+
+// jsCommonJSName names CommonJS exports, e.g., exports for module.exports = { ... }
+// & exports::foo for exports.foo = function () {}
+func jsCommonJSName(node *tree_sitter.Node, source []byte) string {
+	if node.NamedChildCount() == 0 || node.NamedChild(0).Kind() != "assignment_expression" {
+		return ""
+	}
+
+	left := node.NamedChild(0).ChildByFieldName("left")
+	if left == nil || left.Kind() != "member_expression" {
+		return ""
+	}
+
+	if left.Utf8Text(source) == "module.exports" {
+		return "exports"
+	}
+
+	object, property := left.ChildByFieldName("object"), left.ChildByFieldName("property")
+	if object == nil || property == nil {
+		return ""
+	}
+
+	switch object.Utf8Text(source) {
+	case "exports", "module.exports":
+		return "exports::" + property.Utf8Text(source)
+	}
+
+	return ""
+}
+
+// jsName names a declaration after its own name rather than via its NameQuery, which also
+// matches the declarations nested within it, e.g., the functions within a component
+func jsName(node *tree_sitter.Node, source []byte) string {
+	var name *tree_sitter.Node
+	switch node.Kind() {
+	case "lexical_declaration", "variable_declaration":
+		// Declarations of several variables aren't named after any one of them
+		var declarators []*tree_sitter.Node
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			if node.NamedChild(i).Kind() == "variable_declarator" {
+				declarators = append(declarators, node.NamedChild(i))
+			}
+		}
+
+		if len(declarators) != 1 {
+			return ""
+		}
+		name = declarators[0].ChildByFieldName("name")
+	case "ambient_declaration":
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			if node.NamedChild(i).Kind() == "variable_declaration" {
+				return jsName(node.NamedChild(i), source)
+			}
+		}
+
+		return ""
+	case "pair":
+		name = node.ChildByFieldName("key")
+		if name != nil && name.Kind() == "string" && name.NamedChildCount() == 1 {
+			name = name.NamedChild(0)
+		}
+	case "field_definition":
+		name = node.ChildByFieldName("property")
+		if name == nil {
+			name = node.ChildByFieldName("name")
+		}
+	default:
+		name = node.ChildByFieldName("name")
+	}
+
+	if name == nil {
+		return ""
+	}
+
+	switch name.Kind() {
+	case "identifier", "type_identifier", "property_identifier", "string_fragment":
+		return name.Utf8Text(source)
+	}
+
+	return ""
+}
+
+// React roles, appended to the kinds of components & hooks, e.g., lexical_declaration hook
+const (
+	reactComponent = "component"
+	reactHook      = "hook"
+)
+
+// jsReactKind classifies React components, i.e., capitalized functions that render JSX or classes
+// extending Component, & hooks, i.e., functions named useSomething
+func jsReactKind(node *tree_sitter.Node, source []byte) string {
+	name, function := jsDeclaredFunction(node)
+	if name == nil {
+		return ""
+	}
+	identifier := name.Utf8Text(source)
+
+	if node.Kind() == "class_declaration" {
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			heritage := node.NamedChild(i)
+			if heritage.Kind() == "class_heritage" && isReactComponentClass(heritage.Utf8Text(source)) {
+				return reactComponent
+			}
+		}
+
+		return ""
+	}
+
+	if function == nil {
+		return ""
+	}
+
+	runes := []rune(identifier)
+	switch {
+	case len(runes) > 3 && strings.HasPrefix(identifier, "use") && unicode.IsUpper(runes[3]):
+		return reactHook
+	case unicode.IsUpper(runes[0]) && containsJSX(function):
+		return reactComponent
+	}
+
+	return ""
+}
+
+// jsDeclaredFunction returns the name of a declaration & the function it declares, if any,
+// looking through wrappers, e.g., const Button = memo((props) => ...)
+func jsDeclaredFunction(node *tree_sitter.Node) (*tree_sitter.Node, *tree_sitter.Node) {
+	switch node.Kind() {
+	case "function_declaration", "generator_function_declaration":
+		return node.ChildByFieldName("name"), node
+	case "class_declaration":
+		return node.ChildByFieldName("name"), nil
+	}
+
+	var declarator *tree_sitter.Node
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		if node.NamedChild(i).Kind() == "variable_declarator" {
+			declarator = node.NamedChild(i)
+			break
+		}
+	}
+
+	if declarator == nil {
+		return nil, nil
+	}
+
+	name := declarator.ChildByFieldName("name")
+	value := declarator.ChildByFieldName("value")
+	for value != nil && value.Kind() == "call_expression" {
+		arguments := value.ChildByFieldName("arguments")
+		if arguments == nil || arguments.NamedChildCount() == 0 {
+			return name, nil
+		}
+		value = arguments.NamedChild(0)
+	}
+
+	if value == nil {
+		return name, nil
+	}
+
+	switch value.Kind() {
+	case "arrow_function", "function_expression", "function", "generator_function":
+		return name, value
+	}
+
+	return name, nil
+}
+
+// isReactComponentClass reports whether a class heritage extends a React component,
+// e.g., extends React.Component or extends PureComponent<Props>
+func isReactComponentClass(heritage string) bool {
+	for _, base := range []string{"Component", "PureComponent"} {
+		for _, prefix := range []string{"extends ", "extends React."} {
+			if strings.HasPrefix(heritage, prefix+base) {
+				rest := strings.TrimPrefix(heritage, prefix+base)
+				if rest == "" || strings.ContainsAny(rest[:1], "< {") {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// containsJSX reports whether a node renders JSX, e.g., <div />
+func containsJSX(node *tree_sitter.Node) bool {
+	switch node.Kind() {
+	case "jsx_element", "jsx_self_closing_element":
+		return true
+	}
+
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		if containsJSX(node.NamedChild(i)) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func NewJavaScriptParser(workspaceRoot string) (*Parser, error) {
 	parser := tree_sitter.NewParser()
 	parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_javascript.Language()))
@@ -33,7 +33,7 @@ func (s *JavaScriptParserTestSuite) TestFunctionParsing() {
 		{
 			name:    "Simple Function",
 			path:    "simple_function",
-			summary: "function simple_function() {",
+			summary: "Test file for JavaScript function definitions Simple function declaration",
 			source: `// Test file for JavaScript function definitions
 
 // Simple function declaration
@@ -46,7 +46,7 @@ function simple_function() {
 		{
 			name:    "Function With Params",
 			path:    "function_with_params",
-			summary: "function function_with_params(a, b) {",
+			summary: "Function with parameters and return value",
 			source: `// Function with parameters and return value
 function function_with_params(a, b) {
     return a + b;
@@ -57,7 +57,7 @@ function function_with_params(a, b) {
 		{
 			name:    "Arrow Function",
 			path:    "arrow_function",
-			summary: "const arrow_function = () => {",
+			summary: "Arrow function assigned to variable",
 			source: `// Arrow function assigned to variable
 const arrow_function = () => {
     return "arrow";
@@ -68,7 +68,7 @@ const arrow_function = () => {
 		{
 			name:    "Arrow With Params",
 			path:    "arrow_with_params",
-			summary: "const arrow_with_params = (x, y) => {",
+			summary: "Arrow function with parameters",
 			source: `// Arrow function with parameters
 const arrow_with_params = (x, y) => {
     return x * y;
@@ -79,7 +79,7 @@ const arrow_with_params = (x, y) => {
 		{
 			name:    "Function Expression",
 			path:    "function_expression",
-			summary: "const function_expression = function() {",
+			summary: "Function expression assigned to variable",
 			source: `// Function expression assigned to variable
 const function_expression = function() {
     return "expression";
@@ -90,7 +90,7 @@ const function_expression = function() {
 		{
 			name:    "Named Function Expression",
 			path:    "named_function_expression",
-			summary: "const named_function_expression = function namedFn() {",
+			summary: "Named function expression",
 			source: `// Named function expression
 const named_function_expression = function namedFn() {
     return "named expression";
@@ -101,7 +101,7 @@ const named_function_expression = function namedFn() {
 		{
 			name:    "Async Function",
 			path:    "async_function",
-			summary: "async function async_function() {",
+			summary: "Async function",
 			source: `// Async function
 async function async_function() {
     return "async result";
@@ -112,7 +112,7 @@ async function async_function() {
 		{
 			name:    "Async Arrow",
 			path:    "async_arrow",
-			summary: "const async_arrow = async () => {",
+			summary: "Async arrow function",
 			source: `// Async arrow function
 const async_arrow = async () => {
     return "async arrow";
@@ -123,7 +123,7 @@ const async_arrow = async () => {
 		{
 			name:    "Generator Function",
 			path:    "generator_function",
-			summary: "function* generator_function() {",
+			summary: "Generator function",
 			source: `// Generator function
 function* generator_function() {
     yield 1;
@@ -135,7 +135,7 @@ function* generator_function() {
 		{
 			name:    "Exported Function",
 			path:    "exported_function",
-			summary: "function exported_function(input) {",
+			summary: "Exported functions",
 			source: `// Exported functions
 export function exported_function(input) {
     return input.length > 0;
@@ -146,7 +146,7 @@ export function exported_function(input) {
 		{
 			name:    "Async Exported Function",
 			path:    "async_exported_function",
-			summary: "async function async_exported_function(data) {",
+			summary: "async exported function: async function async_exported_function(data)",
 			source: `export async function async_exported_function(data) {
     return await Promise.resolve(data);
 }`,
@@ -156,7 +156,7 @@ export function exported_function(input) {
 		{
 			name:    "Default Exported Function",
 			path:    "default_exported_function",
-			summary: "function default_exported_function() {",
+			summary: "default exported function: function default_exported_function()",
 			source: `export default function default_exported_function() {
     return "default";
 }`,
@@ -166,7 +166,7 @@ export function exported_function(input) {
 		{
 			name:    "Exported Generator",
 			path:    "exported_generator",
-			summary: "function* exported_generator() {",
+			summary: "exported generator: function* exported_generator()",
 			source: `export function* exported_generator() {
     yield "a";
     yield "b";
@@ -207,7 +207,7 @@ func (s *JavaScriptParserTestSuite) TestClassParsing() {
 		{
 			name:    "Simple Class",
 			path:    "SimpleClass",
-			summary: "class SimpleClass {",
+			summary: "Test file for JavaScript class definitions Simple class with no methods",
 			source: `// Test file for JavaScript class definitions
 
 // Simple class with no methods
@@ -219,7 +219,7 @@ class SimpleClass {
 		{
 			name:    "Class With Methods",
 			path:    "ClassWithMethods",
-			summary: "class ClassWithMethods {",
+			summary: "Class with constructor and methods",
 			source: `// Class with constructor and methods
 class ClassWithMethods {
     constructor(value) {
@@ -252,7 +252,7 @@ class ClassWithMethods {
 		{
 			name:    "Extended Class",
 			path:    "ExtendedClass",
-			summary: "class ExtendedClass extends ClassWithMethods {",
+			summary: "Class with inheritance",
 			source: `// Class with inheritance
 class ExtendedClass extends ClassWithMethods {
     constructor(value, name) {
@@ -276,7 +276,7 @@ class ExtendedClass extends ClassWithMethods {
 		{
 			name:    "Class With Privates",
 			path:    "ClassWithPrivates",
-			summary: "class ClassWithPrivates {",
+			summary: "Class with private fields",
 			source: `// Class with private fields
 class ClassWithPrivates {
     #privateField = 42;
@@ -291,7 +291,7 @@ class ClassWithPrivates {
 		{
 			name:    "Exported Class",
 			path:    "ExportedClass",
-			summary: "class ExportedClass {",
+			summary: "Exported classes",
 			source: `// Exported classes
 export class ExportedClass {
     constructor(value) {
@@ -308,7 +308,7 @@ export class ExportedClass {
 		{
 			name:    "Exported APIProvider",
 			path:    "APIProvider",
-			summary: "class APIProvider {",
+			summary: "api provider: class APIProvider",
 			source: `export class APIProvider {
     constructor(name) {
         this.name = name;
@@ -324,7 +324,7 @@ export class ExportedClass {
 		{
 			name:    "Default Exported Plugin",
 			path:    "DefaultPlugin",
-			summary: "class DefaultPlugin {",
+			summary: "default plugin: class DefaultPlugin",
 			source: `export default class DefaultPlugin {
     constructor(config) {
         this.config = config;
@@ -337,7 +337,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::constructor",
 			path:    "ClassWithMethods::constructor",
-			summary: "constructor(value) {",
+			summary: "constructor: constructor(value)",
 			source: `constructor(value) {
         this.value = value;
     }`,
@@ -347,7 +347,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::getValue",
 			path:    "ClassWithMethods::getValue",
-			summary: "getValue() {",
+			summary: "Instance method",
 			source: `// Instance method
     getValue() {
         return this.value;
@@ -358,7 +358,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::setValue",
 			path:    "ClassWithMethods::setValue",
-			summary: "setValue(newValue) {",
+			summary: "Setter method",
 			source: `// Setter method
     setValue(newValue) {
         this.value = newValue;
@@ -369,7 +369,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::createDefault",
 			path:    "ClassWithMethods::createDefault",
-			summary: "static createDefault() {",
+			summary: "Static method",
 			source: `// Static method
     static createDefault() {
         return new ClassWithMethods(0);
@@ -380,7 +380,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::displayValue",
 			path:    "ClassWithMethods::displayValue",
-			summary: "get displayValue() {",
+			summary: "Getter method",
 			source: `// Getter method
     get displayValue() {
         return ` + "`Value: ${this.value}`" + `;
@@ -392,7 +392,7 @@ export class ExportedClass {
 		{
 			name:    "ExtendedClass::constructor",
 			path:    "ExtendedClass::constructor",
-			summary: "constructor(value, name) {",
+			summary: "constructor: constructor(value, name)",
 			source: `constructor(value, name) {
         super(value);
         this.name = name;
@@ -403,7 +403,7 @@ export class ExportedClass {
 		{
 			name:    "ExtendedClass::getValue",
 			path:    "ExtendedClass::getValue",
-			summary: "getValue() {",
+			summary: "Override parent method",
 			source: `// Override parent method
     getValue() {
         return ` + "`${this.name}: ${this.value}`" + `;
@@ -414,7 +414,7 @@ export class ExportedClass {
 		{
 			name:    "ExtendedClass::getName",
 			path:    "ExtendedClass::getName",
-			summary: "getName() {",
+			summary: "New method",
 			source: `// New method
     getName() {
         return this.name;
@@ -426,7 +426,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithPrivates::getPrivate",
 			path:    "ClassWithPrivates::getPrivate",
-			summary: "getPrivate() {",
+			summary: "get private: getPrivate()",
 			source: `getPrivate() {
         return this.#privateField;
     }`,
@@ -437,7 +437,7 @@ export class ExportedClass {
 		{
 			name:    "ExportedClass::constructor",
 			path:    "ExportedClass::constructor",
-			summary: "constructor(value) {",
+			summary: "constructor: constructor(value)",
 			source: `constructor(value) {
         this.value = value;
     }`,
@@ -447,7 +447,7 @@ export class ExportedClass {
 		{
 			name:    "ExportedClass::getValue",
 			path:    "ExportedClass::getValue",
-			summary: "getValue() {",
+			summary: "get value: getValue()",
 			source: `getValue() {
         return this.value;
     }`,
@@ -458,7 +458,7 @@ export class ExportedClass {
 		{
 			name:    "APIProvider::constructor",
 			path:    "APIProvider::constructor",
-			summary: "constructor(name) {",
+			summary: "constructor: constructor(name)",
 			source: `constructor(name) {
         this.name = name;
     }`,
@@ -468,7 +468,7 @@ export class ExportedClass {
 		{
 			name:    "APIProvider::process",
 			path:    "APIProvider::process",
-			summary: "process() {",
+			summary: "process: process()",
 			source: `process() {
         return ` + "`Processing: ${this.name}`" + `;
     }`,
@@ -479,7 +479,7 @@ export class ExportedClass {
 		{
 			name:    "DefaultPlugin::constructor",
 			path:    "DefaultPlugin::constructor",
-			summary: "constructor(config) {",
+			summary: "constructor: constructor(config)",
 			source: `constructor(config) {
         this.config = config;
     }`,
@@ -520,7 +520,7 @@ func (s *JavaScriptParserTestSuite) TestTestFileParsing() {
 		{
 			name:      "File Comment",
 			path:      "85bcbce29203af48",
-			summary:   "// Test file for JavaScript test patterns",
+			summary:   "Test file for JavaScript test patterns",
 			source:    `// Test file for JavaScript test patterns`,
 			startLine: 1,
 			endLine:   1,
@@ -529,7 +529,7 @@ func (s *JavaScriptParserTestSuite) TestTestFileParsing() {
 		{
 			name:    "Test Simple Function",
 			path:    "test_simple_function",
-			summary: "function test_simple_function() {",
+			summary: "test simple function: function test_simple_function()",
 			source: `function test_simple_function() {
     expect(true).toBe(true);
 }`,
@@ -540,7 +540,7 @@ func (s *JavaScriptParserTestSuite) TestTestFileParsing() {
 		{
 			name:    "Test Sample Class",
 			path:    "TestSample",
-			summary: "class TestSample {",
+			summary: "test sample: class TestSample",
 			source: `class TestSample {
     test_method() {
         expect(1 + 1).toBe(2);
@@ -605,7 +605,7 @@ func (s *JavaScriptParserTestSuite) TestVariableParsing() {
 		{
 			name:    "Simple Var",
 			path:    "simple_var",
-			summary: "var simple_var = \"hello\";",
+			summary: "Test file for JavaScript variable declarations var declarations",
 			source: `// Test file for JavaScript variable declarations
 
 // var declarations
@@ -624,7 +624,7 @@ var simple_var = "hello";`,
 		{
 			name:      "Initialized Var",
 			path:      "initialized_var",
-			summary:   "var initialized_var = 42;",
+			summary:   "initialized var: var initialized_var = 42;",
 			source:    `var initialized_var = 42;`,
 			startLine: 6,
 			endLine:   6,
@@ -632,7 +632,7 @@ var simple_var = "hello";`,
 		{
 			name:    "Simple Let",
 			path:    "simple_let",
-			summary: "let simple_let = \"world\";",
+			summary: "let declarations",
 			source: `// let declarations
 let simple_let = "world";`,
 			startLine: 8,
@@ -641,7 +641,7 @@ let simple_let = "world";`,
 		{
 			name:      "Destructured Let",
 			path:      "destructured_let",
-			summary:   "let destructured_let = {a: 1, b: 2};",
+			summary:   "destructured let: let destructured_let = {a: 1, b: 2};",
 			source:    `let destructured_let = {a: 1, b: 2};`,
 			startLine: 10,
 			endLine:   10,
@@ -649,7 +649,7 @@ let simple_let = "world";`,
 		{
 			name:      "Array Destructured",
 			path:      "array_destructured",
-			summary:   "let array_destructured = [1, 2, 3];",
+			summary:   "array destructured: let array_destructured = [1, 2, 3];",
 			source:    `let array_destructured = [1, 2, 3];`,
 			startLine: 11,
 			endLine:   11,
@@ -657,7 +657,7 @@ let simple_let = "world";`,
 		{
 			name:    "Simple Const",
 			path:    "simple_const",
-			summary: "const simple_const = \"constant\";",
+			summary: "const declarations",
 			source: `// const declarations
 const simple_const = "constant";`,
 			startLine: 13,
@@ -666,7 +666,7 @@ const simple_const = "constant";`,
 		{
 			name:    "Object Const",
 			path:    "object_const",
-			summary: "const object_const = {",
+			summary: "object const: const object_const =",
 			source: `const object_const = {
     name: "test",
     value: 123
@@ -677,7 +677,7 @@ const simple_const = "constant";`,
 		{
 			name:      "Arrow Function Const",
 			path:      "arrow_function_const",
-			summary:   "const arrow_function_const = (x) => x * 2;",
+			summary:   "arrow function const: const arrow_function_const = (x) => x * 2;",
 			source:    "const arrow_function_const = (x) => x * 2;",
 			startLine: 19,
 			endLine:   19,
@@ -685,7 +685,7 @@ const simple_const = "constant";`,
 		{
 			name:    "Function Expression",
 			path:    "function_expression",
-			summary: "const function_expression = function(a, b) {",
+			summary: "Function expressions assigned to variables",
 			source: `// Function expressions assigned to variables
 const function_expression = function(a, b) {
     return a + b;
@@ -696,7 +696,7 @@ const function_expression = function(a, b) {
 		{
 			name:    "Named Function Expression",
 			path:    "named_function_expression",
-			summary: "const named_function_expression = function calculator(x, y) {",
+			summary: "named function expression: const named_function_expression = function calculator(x,...",
 			source: `const named_function_expression = function calculator(x, y) {
     return x - y;
 };`,
@@ -706,7 +706,7 @@ const function_expression = function(a, b) {
 		{
 			name:    "Simple Arrow",
 			path:    "simple_arrow",
-			summary: "const simple_arrow = () => \"simple\";",
+			summary: "Arrow functions with different syntaxes",
 			source: `// Arrow functions with different syntaxes
 const simple_arrow = () => "simple";`,
 			startLine: 30,
@@ -715,15 +715,15 @@ const simple_arrow = () => "simple";`,
 		{
 			name:      "Arrow With Params",
 			path:      "arrow_with_params",
-			summary:   "const arrow_with_params = (a, b) => a + b;",
+			summary:   "arrow with params: const arrow_with_params = (a, b) => a + b;",
 			source:    "const arrow_with_params = (a, b) => a + b;",
 			startLine: 32,
 			endLine:   32,
 		},
 		{
 			name:    "Arrow With Body",
-			path:    "2370e5908d333e83",
-			summary: "const arrow_with_body = (x) => {",
+			path:    "arrow_with_body",
+			summary: "arrow with body: const arrow_with_body = (x) =>",
 			source: `const arrow_with_body = (x) => {
     const result = x * 2;
     return result;
@@ -733,8 +733,8 @@ const simple_arrow = () => "simple";`,
 		},
 		{
 			name:    "Async Arrow Func",
-			path:    "7d9a98184af91c01",
-			summary: "const async_arrow_func = async (data) => {",
+			path:    "async_arrow_func",
+			summary: "Async arrow function",
 			source: `// Async arrow function
 const async_arrow_func = async (data) => {
     const response = await fetch(data);
@@ -746,7 +746,7 @@ const async_arrow_func = async (data) => {
 		{
 			name:    "Destructuring Assignment",
 			path:    "69d42be7a90295c0",
-			summary: "let {name, age} = person;",
+			summary: "Complex variable declarations",
 			source: `// Complex variable declarations
 let {name, age} = person;`,
 			startLine: 44,
@@ -763,7 +763,7 @@ let {name, age} = person;`,
 		{
 			name:      "Global Var",
 			path:      "globalVar",
-			summary:   "var globalVar = window.something || \"default\";",
+			summary:   `global var: var globalVar = window.something || "default";`,
 			source:    `var globalVar = window.something || "default";`,
 			startLine: 47,
 			endLine:   47,
@@ -771,7 +771,7 @@ let {name, age} = person;`,
 		{
 			name:    "Exported Const",
 			path:    "EXPORTED_CONST",
-			summary: "const EXPORTED_CONST = \"constant value\";",
+			summary: "Exported variables",
 			source: `// Exported variables
 export const EXPORTED_CONST = "constant value";`,
 			startLine: 49,
@@ -780,7 +780,7 @@ export const EXPORTED_CONST = "constant value";`,
 		{
 			name:      "Exported API_KEY",
 			path:      "API_KEY",
-			summary:   "const API_KEY = \"api-key-12345\";",
+			summary:   `api key: const API_KEY = "api-key-12345";`,
 			source:    `export const API_KEY = "api-key-12345";`,
 			startLine: 51,
 			endLine:   51,
@@ -788,7 +788,7 @@ export const EXPORTED_CONST = "constant value";`,
 		{
 			name:      "Exported Let",
 			path:      "exported_let",
-			summary:   "let exported_let = 42;",
+			summary:   "exported let: let exported_let = 42;",
 			source:    `export let exported_let = 42;`,
 			startLine: 52,
 			endLine:   52,
@@ -812,6 +812,76 @@ export const EXPORTED_CONST = "constant value";`,
 	}
 }
 
+func (s *JavaScriptParserTestSuite) TestObjectAndCommonJSParsing() {
+	chunks := s.getChunks("javascript/api.js")
+
+	tests := []struct {
+		name    string
+		path    string
+		kind    string
+		summary string
+	}{
+		{
+			name:    "Object Of Methods",
+			path:    "api",
+			kind:    "lexical_declaration",
+			summary: "API client for the users service",
+		},
+		{
+			name:    "Object Method",
+			path:    "api::getUser",
+			kind:    "method_definition",
+			summary: "Fetches a user by ID",
+		},
+		{
+			name:    "Object Arrow Function",
+			path:    "api::deleteUser",
+			kind:    "pair",
+			summary: "delete user: deleteUser: async (id) =>",
+		},
+		{
+			name:    "Exported Arrow Function",
+			path:    "handler",
+			kind:    "lexical_declaration",
+			summary: "handler: const handler = async (event) =>",
+		},
+		{
+			name:    "Module Exports",
+			path:    "exports",
+			kind:    "expression_statement",
+			summary: "exports: module.exports =",
+		},
+		{
+			name:    "Module Exports Member",
+			path:    "exports::parseUser",
+			kind:    "pair",
+			summary: "parse user: parseUser: (raw) => JSON.parse(raw)",
+		},
+		{
+			name:    "Exports Assignment",
+			path:    "exports::version",
+			kind:    "expression_statement",
+			summary: "version: exports.version = function ()",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			chunk, exists := chunks[test.path]
+			s.Require().True(exists, "chunk %s not found", test.path)
+			s.Equal(test.kind, chunk.Kind)
+			s.Equal(test.summary, chunk.Summary)
+			s.Equal("javascript/api.js::"+test.path, chunk.ID())
+		})
+	}
+
+	// Objects within functions, shorthand properties & properties that aren't functions aren't members
+	s.NotContains(chunks, "formatUser::short")
+	s.NotContains(chunks, "exports::formatUser")
+	s.NotContains(chunks, "api::baseURL")
+	s.NotContains(chunks, "exports::service")
+}
+
 func (s *JavaScriptParserTestSuite) TearDownSuite() {
 	if s.parser != nil {
 		s.parser.Close()
@@ -6,6 +6,7 @@ import (
 )
 
 var MarkdownSpec = &LanguageSpec{
+	Language:          "markdown",
 	ExtractChildrenIn: []string{"section"},
 	SkipTypes: []string{
 		// Headings are organizational markers, not containers.
@@ -25,6 +26,10 @@ var MarkdownSpec = &LanguageSpec{
 	FileTypeRules: []FileTypeRule{
 		{Pattern: "**/*.md", Type: FileTypeDocs},
 	},
+	// Section paths are content hashes, so they add nothing to the embedding
+	EmbeddingTemplate: `file: {{.File}}
+
+{{.Source}}`,
 }
 
 func NewMarkdownParser(workspaceRoot string) (*Parser, error) {
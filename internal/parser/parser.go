@@ -6,8 +6,11 @@ import (
 	"os"
 	"path"
 	"slices"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
+	"unicode"
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/cespare/xxhash"
@@ -16,6 +19,25 @@ import (
 
 const (
 	chunkSummaryMaxChars = 80
+
+	// DefaultMaxChunkTokens is the size above which chunks are split into parts for embedding
+	DefaultMaxChunkTokens = 1024
+
+	// DefaultEmbeddingTemplate composes the text embedded for a chunk, see EmbeddingData
+	DefaultEmbeddingTemplate = `file: {{.File}}
+language: {{.Language}}
+chunk: {{.Path}}
+{{- with .ParentSignature}}
+parent: {{.}}
+{{- end}}
+{{- with .Doc}}
+doc: {{.}}
+{{- end}}
+{{- with .Description}}
+description: {{.}}
+{{- end}}
+
+{{.Source}}`
 )
 
 // FileType represents the classification of a file within the workspace
@@ -30,25 +52,51 @@ const (
 
 // File represents a parsed source file with its extracted semantic chunks
 type File struct {
-	Path   string // path within workspace
-	Chunks []*Chunk
-	Source []byte
+	Path       string   // path within workspace
+	Package    string   // package name, e.g., from Go's package clause
+	ImportPath string   // package import path, e.g., derived from go.mod
+	Exports    []Export // names the file re-exports from other files, e.g., a Python package's __init__.py
+	Chunks     []*Chunk
+	Source     []byte
 
 	tree *tree_sitter.Tree
 }
 
+// Export is a name that a file re-exports from another file, e.g., from .models import User
+type Export struct {
+	Name   string // exported name, * for wildcard imports
+	Target string // ID prefix of what's exported, e.g., pkg/models.py::User, or a file for modules & wildcards
+}
+
 // Chunk represents a semantic unit of code extracted from source files
 type Chunk struct {
 	File        string // file path within workspace
 	Type        string
 	Path        string // path within file
+	Name        string // symbol name for named chunks, empty for content-hashed ones
+	Kind        string // tree-sitter node kind, e.g., function_declaration
+	Language    string
+	Signature   string // first line of the declaration, excluding folded comments
+	Doc         string // doc comment or docstring, without comment markers
+	Description string // natural-language description from a summarizer, if any
 	Summary     string
+	Aliases     []string // other names the chunk declares, e.g., Y for X, Y int
 	Source      string
+	Code        string // source without comments, e.g., to spot copy-pasted code
 	StartLine   uint
 	StartColumn uint
 	EndLine     uint
 	EndColumn   uint
 	ParsedAt    int64
+	Parts       []*Chunk // parts of an oversized chunk, embedded in its place
+	Package     string   // package the chunk belongs to, if the language has them
+	ImportPath  string   // import path of the chunk's package
+
+	ParentSignature string // signature of the enclosing chunk, e.g., the class of a method
+	EmbeddingText   string // text embedded for the chunk, i.e., the source with its context
+
+	startByte uint
+	endByte   uint
 }
 
 // ID returns a unique identifier for this chunk in the format "file::path"
@@ -81,17 +129,381 @@ func (p *Parser) newChunk(
 
 	summaryText := summaryNode.Utf8Text(source)
 	fullText := source[startByte:endByte]
+	doc := p.extractDoc(summaryNode, source, folded)
+
+	name := ""
+	kind := summaryNode.Kind()
+	var aliases []string
+	if extractor != nil {
+		segments := strings.Split(path, "::")
+		name = segments[len(segments)-1]
+
+		if extractor.Kind != nil {
+			role := extractor.Kind(node, source)
+			if role != "" {
+				kind += " " + role
+			}
+		}
 
-	return &Chunk{
+		if extractor.Aliases != nil {
+			aliases = extractor.Aliases(node, source)
+		}
+	}
+
+	chunk := &Chunk{
 		Path:        finalPath,
 		Type:        string(fileType),
-		Summary:     summarize(summaryText),
+		Name:        name,
+		Kind:        kind,
+		Language:    p.spec.Language,
+		Signature:   firstLine(summaryText),
+		Doc:         doc,
+		Summary:     summarizeChunk(name, doc, summaryNode.Kind(), summaryText),
+		Aliases:     aliases,
 		Source:      string(fullText),
+		Code:        stripComments(node, source, startByte, endByte, folded),
 		StartLine:   startPos.Row + 1,
 		StartColumn: startPos.Column + 1,
 		EndLine:     endPos.Row + 1,
 		EndColumn:   endPos.Column + 1,
 		ParsedAt:    time.Now().Unix(),
+		startByte:   startByte,
+		endByte:     endByte,
+	}
+	chunk.Parts = p.splitChunk(chunk, node, source)
+
+	return chunk
+}
+
+// splitChunk divides an oversized chunk into parts at statement/block boundaries,
+// returning nil if the chunk is small enough or can't be split
+func (p *Parser) splitChunk(chunk *Chunk, node *tree_sitter.Node, source []byte) []*Chunk {
+	maxTokens := p.maxChunkTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxChunkTokens
+	}
+
+	if chunk.Tokens() <= maxTokens {
+		return nil
+	}
+
+	container := splitContainer(node, source, maxTokens)
+
+	type bound struct {
+		pos    tree_sitter.Point
+		offset uint
+	}
+
+	var spans [][2]bound
+	partStart := bound{tree_sitter.Point{Row: chunk.StartLine - 1, Column: chunk.StartColumn - 1}, chunk.startByte}
+	partEnd := partStart
+	var folded *bound // start of the nodes to fold into the next child, e.g., its comments
+	for i := uint(0); i < container.NamedChildCount(); i++ {
+		child := container.NamedChild(i)
+		if slices.Contains(p.spec.FoldIntoNextNode, child.Kind()) {
+			if folded == nil {
+				folded = &bound{child.StartPosition(), child.StartByte()}
+			}
+			continue
+		}
+
+		if partEnd.offset > partStart.offset && EstimateTokens(string(source[partStart.offset:child.EndByte()])) > maxTokens {
+			spans = append(spans, [2]bound{partStart, partEnd})
+			partStart = bound{child.StartPosition(), child.StartByte()}
+			if folded != nil {
+				partStart = *folded
+			}
+		}
+
+		partEnd = bound{child.EndPosition(), child.EndByte()}
+		folded = nil
+	}
+	chunkEnd := bound{tree_sitter.Point{Row: chunk.EndLine - 1, Column: chunk.EndColumn - 1}, chunk.endByte}
+	spans = append(spans, [2]bound{partStart, chunkEnd})
+
+	if len(spans) < 2 {
+		return nil
+	}
+
+	parts := make([]*Chunk, len(spans))
+	for i, span := range spans {
+		partSource := string(source[span[0].offset:span[1].offset])
+		parts[i] = &Chunk{
+			Path:        fmt.Sprintf("%s%s%d", chunk.Path, partSeparator, i+1),
+			Type:        chunk.Type,
+			Kind:        chunk.Kind,
+			Language:    chunk.Language,
+			Signature:   chunk.Signature,
+			Summary:     summarize(partSource),
+			Source:      partSource,
+			StartLine:   span[0].pos.Row + 1,
+			StartColumn: span[0].pos.Column + 1,
+			EndLine:     span[1].pos.Row + 1,
+			EndColumn:   span[1].pos.Column + 1,
+			ParsedAt:    chunk.ParsedAt,
+			startByte:   span[0].offset,
+			endByte:     span[1].offset,
+		}
+	}
+
+	return parts
+}
+
+// splitContainer finds the node whose children a chunk should be split between,
+// descending into oversized children that make up most of the node, e.g., a function's body
+func splitContainer(node *tree_sitter.Node, source []byte, maxTokens int) *tree_sitter.Node {
+	container := node
+	for {
+		var largest *tree_sitter.Node
+		for i := uint(0); i < container.NamedChildCount(); i++ {
+			child := container.NamedChild(i)
+			if largest == nil || nodeSize(child) > nodeSize(largest) {
+				largest = child
+			}
+		}
+
+		if largest == nil || largest.NamedChildCount() == 0 || 2*nodeSize(largest) < nodeSize(container) {
+			return container
+		}
+
+		if EstimateTokens(largest.Utf8Text(source)) <= maxTokens {
+			return container
+		}
+
+		container = largest
+	}
+}
+
+func nodeSize(node *tree_sitter.Node) uint {
+	return node.EndByte() - node.StartByte()
+}
+
+// partSeparator separates a chunk's path from its part number, e.g., Func#2
+const partSeparator = "#"
+
+// PartOf returns the path of the chunk that a part was split from
+func PartOf(path string) (string, bool) {
+	separator := strings.LastIndex(path, partSeparator)
+	if separator < 0 {
+		return "", false
+	}
+
+	parent, number := path[:separator], path[separator+len(partSeparator):]
+	_, err := strconv.Atoi(number)
+	if err != nil {
+		return "", false
+	}
+
+	return parent, true
+}
+
+// stripComments returns the source between startByte & endByte without the comments
+// folded into node or nested within it
+func stripComments(node *tree_sitter.Node, source []byte, startByte, endByte uint, folded []*tree_sitter.Node) string {
+	var comments []*tree_sitter.Node
+	for _, foldedNode := range folded {
+		if foldedNode.Kind() == "comment" {
+			comments = append(comments, foldedNode)
+		}
+	}
+	comments = appendComments(comments, node)
+
+	var code strings.Builder
+	last := startByte
+	for _, comment := range comments {
+		if comment.StartByte() < last || comment.EndByte() > endByte {
+			continue
+		}
+
+		// Keep tokens on either side of the comment apart, e.g., a/* why */b
+		code.Write(source[last:comment.StartByte()])
+		code.WriteByte(' ')
+		last = comment.EndByte()
+	}
+	code.Write(source[last:endByte])
+
+	return code.String()
+}
+
+// appendComments appends the comments within node, in source order
+func appendComments(comments []*tree_sitter.Node, node *tree_sitter.Node) []*tree_sitter.Node {
+	if node.Kind() == "comment" {
+		return append(comments, node)
+	}
+
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		comments = appendComments(comments, node.NamedChild(i))
+	}
+
+	return comments
+}
+
+// extractDoc returns the doc comment of a node, i.e., the comments folded into it
+// or the docstring matched by the language's DocQuery
+func (p *Parser) extractDoc(node *tree_sitter.Node, source []byte, folded []*tree_sitter.Node) string {
+	var comments []string
+	for _, foldedNode := range folded {
+		// Shebangs, e.g., #!/usr/bin/env python3, aren't docs
+		if foldedNode.Kind() == "comment" && !strings.HasPrefix(foldedNode.Utf8Text(source), "#!") {
+			comments = append(comments, foldedNode.Utf8Text(source))
+		}
+	}
+
+	if len(comments) > 0 {
+		return cleanDoc(strings.Join(comments, "\n"))
+	}
+
+	if p.spec.DocQuery == "" {
+		return ""
+	}
+
+	query, err := tree_sitter.NewQuery(p.parser.Language(), p.spec.DocQuery)
+	if err != nil {
+		return ""
+	}
+	defer query.Close()
+
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	// The query also matches nested declarations, only keep the node's own doc
+	matches := cursor.Matches(query, node, source)
+	for match := matches.Next(); match != nil; match = matches.Next() {
+		var doc, owner *tree_sitter.Node
+		for _, capture := range match.Captures {
+			switch query.CaptureNames()[capture.Index] {
+			case "doc":
+				doc = &capture.Node
+			case "owner":
+				owner = &capture.Node
+			}
+		}
+
+		if doc != nil && owner != nil && owner.Id() == node.Id() {
+			return cleanDoc(doc.Utf8Text(source))
+		}
+	}
+
+	return ""
+}
+
+// Markers stripped from the start & end of doc comment lines
+var (
+	docPrefixes = []string{"/**", "/*", "*/", "///", "//", "#", "*", `"""`, "'''"}
+	docSuffixes = []string{"*/", `"""`, "'''"}
+)
+
+// cleanDoc strips comment markers & docstring quotes from doc comment text
+func cleanDoc(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		for _, marker := range docPrefixes {
+			if strings.HasPrefix(line, marker) {
+				line = strings.TrimPrefix(line, marker)
+				break
+			}
+		}
+
+		for _, marker := range docSuffixes {
+			if strings.HasSuffix(line, marker) {
+				line = strings.TrimSuffix(line, marker)
+				break
+			}
+		}
+
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// EmbeddingData is the data available to embedding templates
+type EmbeddingData struct {
+	File            string
+	Language        string
+	Path            string
+	ParentSignature string
+	Doc             string
+	Description     string
+	Source          string
+}
+
+// renderEmbeddingText composes the text embedded for a chunk using the language's template
+func (p *Parser) renderEmbeddingText(chunk *Chunk) string {
+	if p.embeddingTemplate == nil {
+		rawTemplate := p.spec.EmbeddingTemplate
+		if rawTemplate == "" {
+			rawTemplate = DefaultEmbeddingTemplate
+		}
+
+		tmpl, err := template.New(p.spec.Language).Parse(rawTemplate)
+		if err != nil {
+			tmpl = template.Must(template.New("default").Parse(DefaultEmbeddingTemplate))
+		}
+		p.embeddingTemplate = tmpl
+	}
+
+	var text strings.Builder
+	err := p.embeddingTemplate.Execute(&text, EmbeddingData{
+		File:            chunk.File,
+		Language:        chunk.Language,
+		Path:            chunk.Path,
+		ParentSignature: chunk.ParentSignature,
+		Doc:             chunk.Doc,
+		Description:     chunk.Description,
+		Source:          chunk.Source,
+	})
+	if err != nil {
+		return chunk.Source
+	}
+
+	return text.String()
+}
+
+// SnippetEmbeddingText composes the text embedded for a snippet from outside the workspace,
+// e.g., code that's about to be written, so that it can be compared with indexed chunks
+func (p *Parser) SnippetEmbeddingText(source string) string {
+	snippet := &Chunk{Language: p.spec.Language, Source: source}
+
+	file, err := p.ChunkSource("", []byte(source))
+	if err == nil {
+		paths := make(map[string]bool, len(file.Chunks))
+		for _, chunk := range file.Chunks {
+			paths[chunk.Path] = true
+		}
+
+		// Chunks not enclosed by others, e.g., methods are top-level in Go but not in Python
+		var topLevel []*Chunk
+		for _, chunk := range file.Chunks {
+			separator := strings.LastIndex(chunk.Path, "::")
+			if separator < 0 || !paths[chunk.Path[:separator]] {
+				topLevel = append(topLevel, chunk)
+			}
+		}
+
+		// A single declaration is embedded like an indexed one, i.e., with its name & doc
+		if len(topLevel) == 1 {
+			snippet = topLevel[0]
+		}
+	}
+
+	return p.renderEmbeddingText(snippet)
+}
+
+// Describe sets a chunk's generated description, which then summarizes the chunk
+// & gets embedded alongside its code
+func (p *Parser) Describe(chunk *Chunk, description string) {
+	chunk.Description = description
+	chunk.Summary = summarize(firstSentence(description))
+	chunk.EmbeddingText = p.renderEmbeddingText(chunk)
+
+	for _, part := range chunk.Parts {
+		part.Description = description
+		part.EmbeddingText = p.renderEmbeddingText(part)
 	}
 }
 
@@ -132,7 +544,132 @@ func calculateChunkBounds(node *tree_sitter.Node, folded []*tree_sitter.Node) (
 	return startPos, startByte, endPos, endByte
 }
 
-// summarize creates a concise summary from source code, truncating at word boundaries
+// EstimateTokens approximates the number of LLM tokens in text at ~4 characters per token
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// Tokens returns the estimated number of tokens in the chunk's source
+func (c *Chunk) Tokens() int {
+	return EstimateTokens(c.Source)
+}
+
+// Lines returns the chunk's line range, e.g., "line 3" or "lines 3-10"
+func (c *Chunk) Lines() string {
+	if c.StartLine == c.EndLine {
+		return fmt.Sprintf("line %d", c.StartLine)
+	}
+
+	return fmt.Sprintf("lines %d-%d", c.StartLine, c.EndLine)
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(line)
+}
+
+// summarizeChunk describes a chunk in natural language: the first sentence of its doc comment
+// when present, else its name split into words followed by its signature
+func summarizeChunk(name, doc, kind, source string) string {
+	if doc != "" {
+		return summarize(firstSentence(doc))
+	}
+
+	if kind == "comment" {
+		return summarize(firstSentence(cleanDoc(source)))
+	}
+
+	if name == "" {
+		return summarize(source)
+	}
+
+	signature := strings.TrimRight(firstLine(source), " {:")
+	return summarize(strings.Join(SplitIdentifier(name), " ") + ": " + signature)
+}
+
+// firstSentence returns the first sentence of the first paragraph of text
+func firstSentence(text string) string {
+	paragraph, _, _ := strings.Cut(text, "\n\n")
+	paragraph = strings.Join(strings.Fields(paragraph), " ")
+
+	for i := 0; i < len(paragraph)-1; i++ {
+		if strings.ContainsRune(".!?", rune(paragraph[i])) && paragraph[i+1] == ' ' {
+			return paragraph[:i+1]
+		}
+	}
+
+	return paragraph
+}
+
+// SplitIdentifier splits camelCase, PascalCase, snake_case & kebab-case identifiers
+// into lowercase words, e.g., parseHTTPRequest -> parse, http, request
+func SplitIdentifier(identifier string) []string {
+	var words []string
+	var word []rune
+
+	runes := []rune(identifier)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, strings.ToLower(string(word)))
+				word = nil
+			}
+			continue
+		}
+
+		// Start a new word on lower -> upper, e.g., parseHTTP, or at the end of an acronym, e.g., HTTPRequest
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				words = append(words, strings.ToLower(string(word)))
+				word = nil
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	if len(word) > 0 {
+		words = append(words, strings.ToLower(string(word)))
+	}
+
+	return words
+}
+
+// stemSuffixes are stripped by Stem, longest first
+var stemSuffixes = []string{"ings", "ing", "ers", "er", "ed", "es", "s"}
+
+// Stem crudely reduces a lowercase word to its stem so that inflections match,
+// e.g., limiting, limiter & limits -> limit
+func Stem(word string) string {
+	const minStem = 3
+
+	for _, suffix := range stemSuffixes {
+		minLen := minStem
+		if suffix == "s" {
+			if strings.HasSuffix(word, "ss") {
+				break
+			}
+
+			// Plurals of short words, e.g., ids
+			minLen = 2
+		}
+
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= minLen {
+			word = word[:len(word)-len(suffix)]
+			break
+		}
+	}
+
+	if strings.HasSuffix(word, "e") && len(word)-1 >= minStem {
+		word = word[:len(word)-1]
+	}
+
+	return word
+}
+
+// summarize creates a concise summary from text, truncating at word boundaries
 // when the first line exceeds the maximum character limit
 func summarize(source string) string {
 	source = strings.TrimSpace(source)
@@ -157,11 +694,24 @@ func summarize(source string) string {
 
 // LanguageSpec defines language-specific parsing behavior for tree-sitter
 type LanguageSpec struct {
+	Language          string                         // language name, e.g., go
 	NamedChunks       map[string]NamedChunkExtractor // node types that can be extracted by name
 	ExtractChildrenIn []string                       // node types whose children should be recursively processed
 	FoldIntoNextNode  []string                       // node types to fold into next node, e.g., comments
 	SkipTypes         []string                       // node types to completely skip
+	ImportTypes       []string                       // top-level node types that make up the file's imports
+	PackageQuery      string                         // optional query capturing the file's package @name
+	DocQuery          string                         // optional query capturing @doc & its @owner, e.g., docstrings
+	EmbeddingTemplate string                         // optional text/template for embedded text, see EmbeddingData
+	BodyQuery         string                         // query capturing @body nodes to elide in signatures
+	ElidedBody        string                         // replacement for elided bodies, e.g., { ... }
 	FileTypeRules     []FileTypeRule                 // language-specific file type classification rules
+	Groups            map[string]GroupExtractor      // declarations grouping several members, by node type
+
+	// ImportPath optionally derives the import path of a file's package, e.g., from go.mod
+	ImportPath func(workspaceRoot, filePath string) string
+	// Exports optionally lists the names a file re-exports, e.g., a Python package's __init__.py
+	Exports func(workspaceRoot string, file *File) []Export
 }
 
 // NamedChunkExtractor defines tree-sitter queries for extracting named code entities
@@ -169,6 +719,25 @@ type NamedChunkExtractor struct {
 	NameQuery        string // query to extract the entity name
 	ParentNameQuery  string // optional query to extract parent entity name for hierarchical paths
 	SummaryNodeQuery string // optional query to extract a specific node for the summary instead of the main node
+	MembersQuery     string // optional query capturing @members nodes whose children become child chunks, e.g., struct fields
+	// Name optionally names nodes that the NameQuery can't, e.g., setters, returning "" to fall back to the query
+	Name func(node *tree_sitter.Node, source []byte) string
+	// Aliases optionally returns the other names a node declares, e.g., Y in X, Y int
+	Aliases func(node *tree_sitter.Node, source []byte) []string
+	// Kind optionally classifies the role of named chunks beyond their node's kind, e.g., React components,
+	// which is appended to the node's kind, e.g., function_declaration component. "" keeps the node's kind.
+	Kind func(node *tree_sitter.Node, source []byte) string
+	// Skip optionally leaves nodes to their parent chunk, e.g., object properties that aren't methods
+	Skip func(node *tree_sitter.Node, source []byte) bool
+}
+
+// GroupExtractor chunks the members of declarations that group several, e.g., Go's const ( ... ) blocks,
+// on their own. Declarations with a single member are chunked as a whole by their NamedChunkExtractor.
+type GroupExtractor struct {
+	ListTypes         []string // node types wrapping the members, e.g., var_spec_list
+	KeepTogetherQuery string   // optional query matching groups to chunk as a whole, e.g., iota enums
+	// Name names groups that are kept together
+	Name func(group *tree_sitter.Node, source []byte) string
 }
 
 // FileTypeRule defines a pattern-based rule for classifying file types
@@ -199,6 +768,14 @@ type Parser struct {
 	workspaceRoot string              // absolute path to the workspace root
 	parser        *tree_sitter.Parser // tree-sitter parser instance
 	spec          *LanguageSpec       // language-specific parsing configuration
+
+	maxChunkTokens    int                // chunks above this size are split into parts, DefaultMaxChunkTokens if <= 0
+	embeddingTemplate *template.Template // parsed from the spec on first use
+}
+
+// SetMaxChunkTokens sets the size above which chunks are split into parts for embedding
+func (p *Parser) SetMaxChunkTokens(maxTokens int) {
+	p.maxChunkTokens = maxTokens
 }
 
 // parse reads and parses a file using tree-sitter, returning the AST and source
@@ -209,6 +786,11 @@ func (p *Parser) parse(filePath string) (*File, error) {
 		return nil, err
 	}
 
+	return p.parseSource(filePath, source)
+}
+
+// parseSource parses source using tree-sitter, returning the AST
+func (p *Parser) parseSource(filePath string, source []byte) (*File, error) {
 	tree := p.parser.Parse(source, nil)
 	if tree == nil {
 		return nil, fmt.Errorf("couldn't parse %s", filePath)
@@ -233,14 +815,140 @@ func (p *Parser) Chunk(filePath string) (*File, error) {
 		return nil, err
 	}
 
-	file.Chunks = p.extractChunks(file.tree.RootNode(), file.Source, "", fileType, nil)
-	for i := range len(file.Chunks) {
-		file.Chunks[i].File = file.Path
+	p.chunkFile(file, fileType)
+
+	return file, nil
+}
+
+// ChunkSource extracts semantic chunks from source as if it were the contents of filePath,
+// e.g., for past revisions of a file
+func (p *Parser) ChunkSource(filePath string, source []byte) (*File, error) {
+	fileType := p.classifyFileType(filePath)
+	if fileType == FileTypeIgnore {
+		return nil, fmt.Errorf("file %s is marked as ignore", filePath)
 	}
 
+	file, err := p.parseSource(filePath, source)
+	if err != nil {
+		return nil, err
+	}
+
+	p.chunkFile(file, fileType)
+
 	return file, nil
 }
 
+// chunkFile extracts semantic chunks from a parsed file
+func (p *Parser) chunkFile(file *File, fileType FileType) {
+	p.resolvePackage(file)
+
+	file.Chunks = p.extractChunks(file.tree.RootNode(), file.Source, "", fileType, nil)
+	byPath := make(map[string]*Chunk, len(file.Chunks))
+	for _, chunk := range file.Chunks {
+		byPath[chunk.Path] = chunk
+	}
+
+	for _, chunk := range file.Chunks {
+		chunk.File = file.Path
+		chunk.Package = file.Package
+		chunk.ImportPath = file.ImportPath
+
+		separator := strings.LastIndex(chunk.Path, "::")
+		if separator >= 0 {
+			parent, exists := byPath[chunk.Path[:separator]]
+			if exists {
+				chunk.ParentSignature = parent.Signature
+			}
+		}
+		chunk.EmbeddingText = p.renderEmbeddingText(chunk)
+
+		// Parts are embedded in the context of the whole chunk
+		for _, part := range chunk.Parts {
+			part.File = file.Path
+			part.Package = file.Package
+			part.ImportPath = file.ImportPath
+			part.ParentSignature = chunk.Signature
+			part.Doc = chunk.Doc
+			part.EmbeddingText = p.renderEmbeddingText(part)
+		}
+	}
+}
+
+// resolvePackage records the package a file belongs to & what it re-exports, if the language has packages
+func (p *Parser) resolvePackage(file *File) {
+	if p.spec.PackageQuery != "" {
+		names, err := p.executeQuery(p.spec.PackageQuery, file.tree.RootNode(), file.Source)
+		if err != nil || len(names) == 0 {
+			return
+		}
+		file.Package = names[0].Utf8Text(file.Source)
+	}
+
+	if p.spec.ImportPath != nil {
+		file.ImportPath = p.spec.ImportPath(p.workspaceRoot, file.Path)
+	}
+
+	if p.spec.Exports != nil {
+		file.Exports = p.spec.Exports(p.workspaceRoot, file)
+	}
+}
+
+// Imports returns the source of the file's top-level import nodes
+func (p *Parser) Imports(file *File) string {
+	root := file.tree.RootNode()
+
+	var imports []string
+	for i := uint(0); i < root.ChildCount(); i++ {
+		child := root.Child(i)
+		if slices.Contains(p.spec.ImportTypes, child.Kind()) {
+			imports = append(imports, child.Utf8Text(file.Source))
+		}
+	}
+
+	return strings.Join(imports, "\n")
+}
+
+// Skeleton returns the source of a chunk in the file with function & method bodies elided,
+// leaving declarations, fields, and member signatures intact
+func (p *Parser) Skeleton(file *File, chunkPath string) (string, error) {
+	idx := slices.IndexFunc(file.Chunks, func(chunk *Chunk) bool {
+		return chunk.Path == chunkPath
+	})
+	if idx < 0 {
+		return "", fmt.Errorf("chunk %s not found in %s", chunkPath, file.Path)
+	}
+
+	chunk := file.Chunks[idx]
+	if p.spec.BodyQuery == "" {
+		return chunk.Signature, nil
+	}
+
+	bodies, err := p.executeQuery(p.spec.BodyQuery, file.tree.RootNode(), file.Source)
+	if err != nil {
+		return "", err
+	}
+
+	slices.SortFunc(bodies, func(a, b *tree_sitter.Node) int {
+		return int(a.StartByte()) - int(b.StartByte())
+	})
+
+	var skeleton strings.Builder
+	offset := chunk.startByte
+	for _, body := range bodies {
+		// Skip bodies outside the chunk & those nested in an already elided body
+		if body.StartByte() < offset || body.EndByte() > chunk.endByte {
+			continue
+		}
+
+		skeleton.Write(file.Source[offset:body.StartByte()])
+		skeleton.WriteString(p.spec.ElidedBody)
+		offset = body.EndByte()
+	}
+	skeleton.Write(file.Source[offset:chunk.endByte])
+
+	return skeleton.String(), nil
+}
+
 // classifyFileType determines the file type based on path patterns,
 // checking global rules first, then language-specific rules
 func (p *Parser) classifyFileType(filePath string) FileType {
@@ -281,9 +989,22 @@ func (p *Parser) extractChunks(
 			continue
 		}
 
+		members := p.groupMembers(child, source)
+		if members != nil {
+			chunks = append(chunks, p.extractChunks(members, source, parentPath, fileType, nil)...)
+
+			// The group's doc comment doesn't belong to any one member
+			for _, foldedNode := range folded {
+				chunks = append(chunks, p.extractHashedNode(foldedNode, source, usedPaths, fileType, nil))
+			}
+			folded = nil
+			continue
+		}
+
 		chunk, path := p.createChunkFromNode(child, source, parentPath, fileType, usedPaths, folded)
 		if chunk != nil {
 			chunks = append(chunks, chunk)
+			chunks = append(chunks, p.extractMembers(child, source, chunk, fileType)...)
 			folded = nil
 		}
 
@@ -309,6 +1030,79 @@ func (p *Parser) extractChunks(
 	return chunks
 }
 
+// groupMembers returns the node containing the members of a group declaration if they should be
+// chunked on their own, i.e., if it groups several members & isn't kept together
+func (p *Parser) groupMembers(node *tree_sitter.Node, source []byte) *tree_sitter.Node {
+	group, exists := p.spec.Groups[node.Kind()]
+	if !exists {
+		return nil
+	}
+
+	container, nMembers := p.groupContainer(group, node)
+	if nMembers < 2 {
+		return nil
+	}
+
+	if group.KeepTogetherQuery != "" {
+		matches, err := p.executeQuery(group.KeepTogetherQuery, node, source)
+		if err == nil && len(matches) > 0 {
+			return nil
+		}
+	}
+
+	return container
+}
+
+// groupContainer returns the node containing a group's members & how many members it has
+func (p *Parser) groupContainer(group GroupExtractor, node *tree_sitter.Node) (*tree_sitter.Node, int) {
+	container := node
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		child := node.NamedChild(i)
+		if slices.Contains(group.ListTypes, child.Kind()) {
+			container = child
+			break
+		}
+	}
+
+	nMembers := 0
+	for i := uint(0); i < container.NamedChildCount(); i++ {
+		_, named := p.spec.NamedChunks[container.NamedChild(i).Kind()]
+		if named {
+			nMembers++
+		}
+	}
+
+	return container, nMembers
+}
+
+// extractMembers extracts the members of a named chunk as its children, e.g., a struct's fields
+func (p *Parser) extractMembers(node *tree_sitter.Node, source []byte, chunk *Chunk, fileType FileType) []*Chunk {
+	extractor, exists := p.spec.NamedChunks[node.Kind()]
+	if !exists || extractor.MembersQuery == "" || chunk.Name == "" {
+		return nil
+	}
+
+	containers, err := p.executeQuery(extractor.MembersQuery, node, source)
+	if err != nil {
+		return nil
+	}
+
+	// Containers matched by several patterns are captured once per match & the query
+	// also matches nested declarations, e.g., objects declared within a function
+	var members []*Chunk
+	seen := map[uintptr]bool{}
+	for _, container := range containers {
+		if seen[container.Id()] || nestedIn(container, node) {
+			continue
+		}
+		seen[container.Id()] = true
+
+		members = append(members, p.extractChunks(container, source, chunk.Path, fileType, nil)...)
+	}
+
+	return members
+}
+
 // createChunkFromNode creates a chunk from a code node, attempting named extraction first
 // Returns nil chunk if the node type should be skipped, but still returns the path for recursion
 func (p *Parser) createChunkFromNode(
@@ -326,6 +1120,10 @@ func (p *Parser) createChunkFromNode(
 	}
 
 	extractor, exists := p.spec.NamedChunks[kind]
+	if exists && extractor.Skip != nil && extractor.Skip(node, source) {
+		return nil, parentPath
+	}
+
 	if exists {
 		chunkPath, err := p.buildChunkPath(extractor, node, source, parentPath)
 		if err == nil {
@@ -359,7 +1157,7 @@ func (p *Parser) buildChunkPath(
 	source []byte,
 	parentPath string,
 ) (string, error) {
-	path, err := p.getNamedNodePath(extractor.NameQuery, child, source)
+	path, err := p.chunkName(extractor, child, source)
 	if err != nil {
 		return "", err
 	}
@@ -379,6 +1177,31 @@ func (p *Parser) buildChunkPath(
 	return path, nil
 }
 
+// chunkName names a node, preferring the extractor's Name hook over its NameQuery
+func (p *Parser) chunkName(extractor NamedChunkExtractor, node *tree_sitter.Node, source []byte) (string, error) {
+	// Groups of several members that get here are kept together, e.g., iota enums
+	group, isGroup := p.spec.Groups[node.Kind()]
+	if isGroup && group.Name != nil {
+		_, nMembers := p.groupContainer(group, node)
+		if nMembers > 1 {
+			return group.Name(node, source), nil
+		}
+	}
+
+	if extractor.Name != nil {
+		name := extractor.Name(node, source)
+		if name != "" {
+			return name, nil
+		}
+	}
+
+	if extractor.NameQuery == "" {
+		return "", errors.New("no name query")
+	}
+
+	return p.getNamedNodePath(extractor.NameQuery, node, source)
+}
+
 // getNamedNodePath extracts a name from a node using a tree-sitter query
 func (p *Parser) getNamedNodePath(
 	query string,
@@ -401,6 +1224,17 @@ func (p *Parser) getNamedNodePath(
 	return "", errors.New("no matches found")
 }
 
+// nestedIn reports whether a node is within another declaration of the same kind as ancestor
+func nestedIn(node, ancestor *tree_sitter.Node) bool {
+	for parent := node.Parent(); parent != nil && parent.Id() != ancestor.Id(); parent = parent.Parent() {
+		if parent.Kind() == ancestor.Kind() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // executeQuery runs a tree-sitter query against a node and returns all matching nodes
 func (p *Parser) executeQuery(
 	rawQuery string,
@@ -2,8 +2,10 @@ package parser_test
 
 import (
 	"path/filepath"
+	"testing"
 
 	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -30,6 +32,76 @@ func (s *ParserBaseTestSuite) getChunks(filePath string) map[string]*parser.Chun
 	return chunks
 }
 
+func TestPartOf(t *testing.T) {
+	tests := []struct {
+		path   string
+		parent string
+		isPart bool
+	}{
+		{path: "Func#2", parent: "Func", isPart: true},
+		{path: "Type::Method#10", parent: "Type::Method", isPart: true},
+		{path: "Func", isPart: false},
+		{path: "#private", isPart: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			parent, isPart := parser.PartOf(test.path)
+			assert.Equal(t, test.isPart, isPart)
+			assert.Equal(t, test.parent, parent)
+		})
+	}
+}
+
+func TestChunkLines(t *testing.T) {
+	assert.Equal(t, "line 3", (&parser.Chunk{StartLine: 3, EndLine: 3}).Lines())
+	assert.Equal(t, "lines 3-10", (&parser.Chunk{StartLine: 3, EndLine: 10}).Lines())
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		words      []string
+	}{
+		{identifier: "getUserName", words: []string{"get", "user", "name"}},
+		{identifier: "GetName", words: []string{"get", "name"}},
+		{identifier: "parseHTTPRequest", words: []string{"parse", "http", "request"}},
+		{identifier: "snake_case_name", words: []string{"snake", "case", "name"}},
+		{identifier: "VIEW_TYPE", words: []string{"view", "type"}},
+		{identifier: "__init__", words: []string{"init"}},
+		{identifier: "utf8Decoder", words: []string{"utf8", "decoder"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.identifier, func(t *testing.T) {
+			assert.Equal(t, test.words, parser.SplitIdentifier(test.identifier))
+		})
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		words []string
+		stem  string
+	}{
+		{words: []string{"limit", "limits", "limiter", "limiters", "limiting", "limited"}, stem: "limit"},
+		{words: []string{"create", "creates", "created", "creating"}, stem: "creat"},
+		{words: []string{"user", "users"}, stem: "user"},
+		{words: []string{"class", "classes"}, stem: "class"},
+		{words: []string{"type", "types"}, stem: "typ"},
+		{words: []string{"process"}, stem: "process"},
+		{words: []string{"id", "ids"}, stem: "id"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.stem, func(t *testing.T) {
+			for _, word := range test.words {
+				assert.Equal(t, test.stem, parser.Stem(word), word)
+			}
+		})
+	}
+}
+
 func (s *GoParserTestSuite) TearDownSuite() {
 	if s.parser != nil {
 		s.parser.Close()
@@ -1,23 +1,31 @@
 package parser
 
 import (
+	"os"
+	"path"
+	"strings"
+
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 	tree_sitter_python "github.com/tree-sitter/tree-sitter-python/bindings/go"
 )
 
 var PythonSpec = &LanguageSpec{
+	Language: "python",
 	NamedChunks: map[string]NamedChunkExtractor{
 		"function_definition": {
 			NameQuery: `(function_definition name: (identifier) @name)`,
+			Name:      pythonName,
 		},
 		"class_definition": {
 			NameQuery: `(class_definition name: (identifier) @name)`,
+			Name:      pythonName,
 		},
 		"decorated_definition": {
 			NameQuery: `(decorated_definition definition: [
 				(function_definition name: (identifier) @name)
 				(class_definition name: (identifier) @name)
 			])`,
+			Name: pythonDecoratedName,
 			SummaryNodeQuery: `(decorated_definition definition: [
 				(function_definition) @summary
 				(class_definition) @summary
@@ -25,6 +33,14 @@ var PythonSpec = &LanguageSpec{
 		},
 		"expression_statement": {
 			NameQuery: `(expression_statement (assignment left: (identifier) @name))`,
+			Name:      pythonModuleDocName,
+		},
+		"type_alias_statement": {
+			NameQuery: `(type_alias_statement left: (type [(identifier) @name (generic_type (identifier) @name)]))`,
+		},
+		// if __name__ == "__main__": blocks
+		"if_statement": {
+			Name: pythonMainName,
 		},
 	},
 	ExtractChildrenIn: []string{
@@ -46,6 +62,21 @@ var PythonSpec = &LanguageSpec{
 		// Skip container nodes (but still extract their children)
 		"block",
 	},
+	ImportTypes: []string{
+		"import_statement",
+		"import_from_statement",
+		"future_import_statement",
+	},
+	ImportPath: pythonModulePath,
+	Exports:    pythonExports,
+	BodyQuery:  `(function_definition body: (block) @body)`,
+	ElidedBody: "...",
+	DocQuery: `
+		[
+			(function_definition body: (block . (expression_statement (string) @doc))) @owner
+			(class_definition body: (block . (expression_statement (string) @doc))) @owner
+			(expression_statement (string) @doc) @owner
+		]`,
 	FileTypeRules: []FileTypeRule{
 		{Pattern: "**/test*.py", Type: FileTypeTests},
 		{Pattern: "**/*_test.py", Type: FileTypeTests},
@@ -58,6 +89,219 @@ var PythonSpec = &LanguageSpec{
 	},
 }
 
+// pythonOverloads are decorators of alternative signatures, e.g., @overload or @typing.overload
+var pythonOverloads = []string{"overload", "typing.overload"}
+
+// pythonName names a definition after its own name rather than via its NameQuery,
+// which also matches the definitions nested within it, e.g., a function's inner functions
+func pythonName(node *tree_sitter.Node, source []byte) string {
+	name := node.ChildByFieldName("name")
+	if name == nil {
+		return ""
+	}
+
+	return name.Utf8Text(source)
+}
+
+// pythonDecoratedName names decorated definitions after their definition, qualifying the names of those
+// that share a name with another, e.g., name.setter for @name.setter or f.overload for @overload,
+// so that they get stable paths
+func pythonDecoratedName(node *tree_sitter.Node, source []byte) string {
+	definition := node.ChildByFieldName("definition")
+	if definition == nil || definition.ChildByFieldName("name") == nil {
+		return ""
+	}
+	name := definition.ChildByFieldName("name").Utf8Text(source)
+
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		decorator := node.NamedChild(i)
+		if decorator.Kind() != "decorator" || decorator.NamedChildCount() == 0 {
+			continue
+		}
+
+		expression := decorator.NamedChild(0)
+		text := expression.Utf8Text(source)
+		for _, overload := range pythonOverloads {
+			if text == overload {
+				return name + ".overload"
+			}
+		}
+
+		// e.g., @name.setter & @name.deleter
+		object := expression.ChildByFieldName("object")
+		attribute := expression.ChildByFieldName("attribute")
+		if expression.Kind() == "attribute" && object.Utf8Text(source) == name && attribute != nil {
+			return name + "." + attribute.Utf8Text(source)
+		}
+	}
+
+	return name
+}
+
+// pythonModuleDocName names a module's docstring __doc__, like Python does
+func pythonModuleDocName(node *tree_sitter.Node, source []byte) string {
+	if node.Parent() == nil || node.Parent().Kind() != "module" {
+		return ""
+	}
+
+	if node.NamedChildCount() != 1 || node.NamedChild(0).Kind() != "string" {
+		return ""
+	}
+
+	// Docstrings are the first statement, possibly after comments, e.g., a shebang
+	for prev := node.PrevNamedSibling(); prev != nil; prev = prev.PrevNamedSibling() {
+		if prev.Kind() != "comment" {
+			return ""
+		}
+	}
+
+	return "__doc__"
+}
+
+// pythonMainName names a script's if __name__ == "__main__": block __main__
+func pythonMainName(node *tree_sitter.Node, source []byte) string {
+	condition := node.ChildByFieldName("condition")
+	if condition == nil {
+		return ""
+	}
+
+	normalized := strings.ReplaceAll(strings.Join(strings.Fields(condition.Utf8Text(source)), ""), "'", `"`)
+	if normalized == `__name__=="__main__"` || normalized == `"__main__"==__name__` {
+		return "__main__"
+	}
+
+	return ""
+}
+
+// pythonModulePath derives the dotted path a module is imported by from the packages, i.e.,
+// directories with an __init__.py, it's in, e.g., pkg.models for src/pkg/models.py
+func pythonModulePath(workspaceRoot, filePath string) string {
+	if filePath == "" {
+		return ""
+	}
+
+	var segments []string
+	module := strings.TrimSuffix(path.Base(filePath), ".py")
+	if module != "__init__" {
+		segments = append(segments, module)
+	}
+
+	for dir := path.Dir(filePath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if !fileExists(path.Join(workspaceRoot, dir, "__init__.py")) {
+			break
+		}
+
+		segments = append([]string{path.Base(dir)}, segments...)
+	}
+
+	return strings.Join(segments, ".")
+}
+
+// pythonExports lists the names a package's __init__.py imports from within the workspace,
+// which callers then import from the package instead, e.g., from pkg import User
+func pythonExports(workspaceRoot string, file *File) []Export {
+	if path.Base(file.Path) != "__init__.py" {
+		return nil
+	}
+
+	var exports []Export
+	root := file.tree.RootNode()
+	for i := uint(0); i < root.NamedChildCount(); i++ {
+		statement := root.NamedChild(i)
+		if statement.Kind() != "import_from_statement" {
+			continue
+		}
+
+		moduleName := statement.ChildByFieldName("module_name")
+		if moduleName == nil {
+			continue
+		}
+
+		modulePath := pythonImportedPath(workspaceRoot, file.Path, moduleName, file.Source)
+		if modulePath == "" {
+			continue
+		}
+		moduleFile := pythonModuleFile(workspaceRoot, modulePath)
+
+		for j := uint(0); j < statement.NamedChildCount(); j++ {
+			child := statement.NamedChild(j)
+			if child.Kind() == "wildcard_import" && moduleFile != "" {
+				exports = append(exports, Export{Name: "*", Target: moduleFile})
+				continue
+			}
+
+			name, alias := child, child
+			if child.Kind() == "aliased_import" {
+				name, alias = child.ChildByFieldName("name"), child.ChildByFieldName("alias")
+			}
+
+			if name == nil || alias == nil || name.Kind() != "dotted_name" || child.Id() == moduleName.Id() {
+				continue
+			}
+
+			// Submodules are exported as files, e.g., from . import utils
+			submodule := pythonModuleFile(workspaceRoot, path.Join(modulePath, name.Utf8Text(file.Source)))
+			switch {
+			case submodule != "":
+				exports = append(exports, Export{Name: alias.Utf8Text(file.Source), Target: submodule})
+			case moduleFile != "":
+				exports = append(exports, Export{
+					Name:   alias.Utf8Text(file.Source),
+					Target: moduleFile + "::" + name.Utf8Text(file.Source),
+				})
+			}
+		}
+	}
+
+	return exports
+}
+
+// pythonImportedPath resolves an import's module name to a path within the workspace,
+// relative to the importing file for relative imports, e.g., ..models
+func pythonImportedPath(workspaceRoot, filePath string, moduleName *tree_sitter.Node, source []byte) string {
+	if moduleName.Kind() == "relative_import" {
+		dir := path.Dir(filePath)
+		var dotted string
+		for i := uint(0); i < moduleName.NamedChildCount(); i++ {
+			child := moduleName.NamedChild(i)
+			switch child.Kind() {
+			case "import_prefix":
+				for range strings.Count(child.Utf8Text(source), ".") - 1 {
+					dir = path.Dir(dir)
+				}
+			case "dotted_name":
+				dotted = child.Utf8Text(source)
+			}
+		}
+
+		return path.Join(dir, strings.ReplaceAll(dotted, ".", "/"))
+	}
+
+	// Absolute imports are relative to the directory containing the top-level package
+	sourceRoot := path.Dir(filePath)
+	for sourceRoot != "." && fileExists(path.Join(workspaceRoot, sourceRoot, "__init__.py")) {
+		sourceRoot = path.Dir(sourceRoot)
+	}
+
+	return path.Join(sourceRoot, strings.ReplaceAll(moduleName.Utf8Text(source), ".", "/"))
+}
+
+// pythonModuleFile returns the file defining a module, i.e., a module file or a package's __init__.py
+func pythonModuleFile(workspaceRoot, modulePath string) string {
+	for _, candidate := range []string{modulePath + ".py", path.Join(modulePath, "__init__.py")} {
+		if fileExists(path.Join(workspaceRoot, candidate)) {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+func fileExists(filePath string) bool {
+	info, err := os.Stat(filePath)
+	return err == nil && !info.IsDir()
+}
+
 func NewPythonParser(workspaceRoot string) (*Parser, error) {
 	parser := tree_sitter.NewParser()
 	parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_python.Language()))
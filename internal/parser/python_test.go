@@ -31,9 +31,9 @@ func (s *PythonParserTestSuite) TestFunctionParsing() {
 		endLine   int
 	}{
 		{
-			name:      "Docstring Hashing",
-			path:      "b4c6199b06bf4bfb",
-			summary:   `"""Test file for Python function definitions."""`,
+			name:      "Module Docstring",
+			path:      "__doc__",
+			summary:   "Test file for Python function definitions.",
 			source:    `"""Test file for Python function definitions."""`,
 			startLine: 1,
 			endLine:   1,
@@ -41,7 +41,7 @@ func (s *PythonParserTestSuite) TestFunctionParsing() {
 		{
 			name:    "Simple Function",
 			path:    "simple_function",
-			summary: "def simple_function():",
+			summary: "Simple function with no parameters",
 			source: `# Simple function with no parameters
 def simple_function():
     pass`,
@@ -51,7 +51,7 @@ def simple_function():
 		{
 			name:    "Function With Params",
 			path:    "function_with_params",
-			summary: "def function_with_params(a, b):",
+			summary: "Function with parameters and return value",
 			source: `# Function with parameters and return value
 def function_with_params(a, b):
     return a + b`,
@@ -61,7 +61,7 @@ def function_with_params(a, b):
 		{
 			name:    "Decorated Function",
 			path:    "decorated_function",
-			summary: "def decorated_function():",
+			summary: "Property decorator example",
 			source: `# Property decorator example
 @property
 def decorated_function():
@@ -72,7 +72,7 @@ def decorated_function():
 		{
 			name:    "Static Method",
 			path:    "static_method",
-			summary: "def static_method():",
+			summary: "Static method decorator",
 			source: `# Static method decorator
 @staticmethod
 def static_method():
@@ -83,7 +83,7 @@ def static_method():
 		{
 			name:    "Class Method",
 			path:    "class_method",
-			summary: "def class_method(cls):",
+			summary: "Class method decorator",
 			source: `# Class method decorator
 @classmethod
 def class_method(cls):
@@ -94,7 +94,7 @@ def class_method(cls):
 		{
 			name:    "Async Function",
 			path:    "async_function",
-			summary: "async def async_function():",
+			summary: "Async function example",
 			source: `# Async function example
 async def async_function():
     return "async"`,
@@ -104,7 +104,7 @@ async def async_function():
 		{
 			name:    "Generator Function",
 			path:    "generator_function",
-			summary: "def generator_function():",
+			summary: "Generator function example foo bar baz",
 			source: `# Generator function example
 #  foo bar baz
 
@@ -147,7 +147,7 @@ func (s *PythonParserTestSuite) TestClassParsing() {
 		{
 			name:    "Simple Class",
 			path:    "SimpleClass",
-			summary: "class SimpleClass:",
+			summary: "Simple class with no methods",
 			source: `# Simple class with no methods
 class SimpleClass:
     pass`,
@@ -157,7 +157,7 @@ class SimpleClass:
 		{
 			name:    "Class With Methods",
 			path:    "ClassWithMethods",
-			summary: "class ClassWithMethods:",
+			summary: "class with methods: class ClassWithMethods",
 			source: `class ClassWithMethods:
     value = -1
 
@@ -179,7 +179,7 @@ class SimpleClass:
 		{
 			name:    "Inherited Class",
 			path:    "InheritedClass",
-			summary: "class InheritedClass(ClassWithMethods):",
+			summary: "Class with inheritance",
 			source: `# Class with inheritance
 class InheritedClass(ClassWithMethods):
     # Override parent method
@@ -191,7 +191,7 @@ class InheritedClass(ClassWithMethods):
 		{
 			name:    "Decorated Class",
 			path:    "DecoratedClass",
-			summary: "class DecoratedClass:",
+			summary: "Decorated class using dataclass",
 			source: `# Decorated class using dataclass
 @dataclass
 class DecoratedClass:
@@ -204,7 +204,7 @@ class DecoratedClass:
 		{
 			name:      "ClassWithMethods::value",
 			path:      "ClassWithMethods::value",
-			summary:   "value = -1",
+			summary:   "value: value = -1",
 			source:    `value = -1`,
 			startLine: 8,
 			endLine:   8,
@@ -212,7 +212,7 @@ class DecoratedClass:
 		{
 			name:    "ClassWithMethods::__init__",
 			path:    "ClassWithMethods::__init__",
-			summary: "def __init__(self):",
+			summary: "Constructor method",
 			source: `# Constructor method
     def __init__(self):
         self.value = 0`,
@@ -222,7 +222,7 @@ class DecoratedClass:
 		{
 			name:    "ClassWithMethods::method",
 			path:    "ClassWithMethods::method",
-			summary: "def method(self):",
+			summary: "Instance method",
 			source: `# Instance method
     def method(self):
         return self.value`,
@@ -232,7 +232,7 @@ class DecoratedClass:
 		{
 			name:    "ClassWithMethods::property_method",
 			path:    "ClassWithMethods::property_method",
-			summary: "def property_method(self):",
+			summary: "Property method with decorator",
 			source: `# Property method with decorator
     @property
     def property_method(self):
@@ -244,7 +244,7 @@ class DecoratedClass:
 		{
 			name:    "InheritedClass::method",
 			path:    "InheritedClass::method",
-			summary: "def method(self):",
+			summary: "Override parent method",
 			source: `# Override parent method
     def method(self):
         return super().method() + 1`,
@@ -283,9 +283,9 @@ func (s *PythonParserTestSuite) TestTestFileParsing() {
 		fileType  string
 	}{
 		{
-			name:      "Test Simple Function",
-			path:      "e36d1e5889771889",
-			summary:   `"""Test file for Python test patterns."""`,
+			name:      "Module Docstring",
+			path:      "__doc__",
+			summary:   "Test file for Python test patterns.",
 			source:    `"""Test file for Python test patterns."""`,
 			startLine: 1,
 			endLine:   1,
@@ -294,7 +294,7 @@ func (s *PythonParserTestSuite) TestTestFileParsing() {
 		{
 			name:    "Test Simple Function",
 			path:    "test_simple_function",
-			summary: "def test_simple_function():",
+			summary: "test simple function: def test_simple_function()",
 			source: `def test_simple_function():
     assert True`,
 			startLine: 5,
@@ -304,7 +304,7 @@ func (s *PythonParserTestSuite) TestTestFileParsing() {
 		{
 			name:    "Test Sample Class",
 			path:    "TestSample",
-			summary: "class TestSample(unittest.TestCase):",
+			summary: "test sample: class TestSample(unittest.TestCase)",
 			source: `class TestSample(unittest.TestCase):
     def test_method(self):
         self.assertEqual(1 + 1, 2)
@@ -334,6 +334,263 @@ func (s *PythonParserTestSuite) TestTestFileParsing() {
 	}
 }
 
+func (s *PythonParserTestSuite) TestChunkMetadata() {
+	chunks := s.getChunks("python/functions.py")
+
+	chunk, exists := chunks["decorated_function"]
+	s.Require().True(exists, "chunk %s not found", "decorated_function")
+
+	s.Equal("decorated_function", chunk.Name)
+	// Decorated definitions take the kind of the definition they wrap
+	s.Equal("function_definition", chunk.Kind)
+	s.Equal("python", chunk.Language)
+}
+
+func (s *PythonParserTestSuite) TestDocs() {
+	tests := []struct {
+		name string
+		file string
+		path string
+		doc  string
+	}{
+		{
+			name: "Folded Comment",
+			file: "python/functions.py",
+			path: "simple_function",
+			doc:  "Simple function with no parameters",
+		},
+		{
+			name: "Docstring",
+			file: "python/functions.py",
+			path: "documented_function",
+			doc: `Double x.
+Multi-line docstrings keep their paragraphs.`,
+		},
+		{
+			name: "Method Docstring",
+			file: "python/classes.py",
+			path: "UndocumentedClass::method",
+			doc:  "Return one.",
+		},
+		{
+			name: "Nested Docstrings Are Ignored",
+			file: "python/classes.py",
+			path: "UndocumentedClass",
+			doc:  "",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			chunks := s.getChunks(test.file)
+
+			chunk, exists := chunks[test.path]
+			s.Require().True(exists, "chunk %s not found", test.path)
+			s.Equal(test.doc, chunk.Doc)
+		})
+	}
+}
+
+func (s *PythonParserTestSuite) TestImports() {
+	file, err := s.parser.Chunk("python/classes.py")
+	s.Require().NoError(err)
+
+	s.Equal("from dataclasses import dataclass", s.parser.Imports(file))
+}
+
+func (s *PythonParserTestSuite) TestNamedStatements() {
+	chunks := s.getChunks("python/shop/models.py")
+
+	tests := []struct {
+		name    string
+		path    string
+		kind    string
+		summary string
+	}{
+		{
+			name:    "Module Docstring After Shebang",
+			path:    "__doc__",
+			kind:    "expression_statement",
+			summary: "Products sold by the shop.",
+		},
+		{
+			name: "Type Alias Statement",
+			path: "Sku",
+			kind: "type_alias_statement",
+		},
+		{
+			name: "Annotated Type Alias",
+			path: "Tags",
+			kind: "expression_statement",
+		},
+		{
+			name:    "Property Getter",
+			path:    "Product::name",
+			kind:    "function_definition",
+			summary: "The product's display name.",
+		},
+		{
+			name: "Property Setter",
+			path: "Product::name.setter",
+			kind: "function_definition",
+		},
+		{
+			name: "Overloads",
+			path: "Product::discount.overload",
+			kind: "function_definition",
+		},
+		{
+			name: "Second Overload",
+			path: "Product::discount.overload-2",
+			kind: "function_definition",
+		},
+		{
+			name: "Overloaded Implementation",
+			path: "Product::discount",
+			kind: "function_definition",
+		},
+		{
+			name:    "Async Function With Nested Function",
+			path:    "load_catalog",
+			kind:    "function_definition",
+			summary: "Loads products from raw catalog lines.",
+		},
+		{
+			name: "Main Block",
+			path: "__main__",
+			kind: "if_statement",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			chunk, exists := chunks[test.path]
+			s.Require().True(exists, "chunk %s not found", test.path)
+			s.Equal(test.kind, chunk.Kind)
+			if test.summary != "" {
+				s.Equal(test.summary, chunk.Summary)
+			}
+		})
+	}
+}
+
+func (s *PythonParserTestSuite) TestModulePath() {
+	tests := []struct {
+		file       string
+		importPath string
+	}{
+		{file: "python/shop/models.py", importPath: "shop.models"},
+		{file: "python/shop/__init__.py", importPath: "shop"},
+		{file: "python/classes.py", importPath: "classes"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.file, func() {
+			file, err := s.parser.Chunk(test.file)
+			s.Require().NoError(err)
+			s.Equal(test.importPath, file.ImportPath)
+		})
+	}
+}
+
+func (s *PythonParserTestSuite) TestExports() {
+	file, err := s.parser.Chunk("python/shop/__init__.py")
+	s.Require().NoError(err)
+
+	s.Equal([]parser.Export{
+		{Name: "Product", Target: "python/shop/models.py::Product"},
+		{Name: "Amount", Target: "python/shop/models.py::Price"},
+		{Name: "pricing", Target: "python/shop/pricing.py"},
+		{Name: "*", Target: "python/shop/pricing.py"},
+	}, file.Exports)
+
+	// Only packages re-export names
+	file, err = s.parser.Chunk("python/shop/models.py")
+	s.Require().NoError(err)
+	s.Empty(file.Exports)
+}
+
+func (s *PythonParserTestSuite) TestSkeletons() {
+	file, err := s.parser.Chunk("python/classes.py")
+	s.Require().NoError(err)
+
+	skeleton, err := s.parser.Skeleton(file, "ClassWithMethods")
+	s.Require().NoError(err)
+	s.Equal(`class ClassWithMethods:
+    value = -1
+
+    # Constructor method
+    def __init__(self):
+        ...
+
+    # Instance method
+    def method(self):
+        ...
+
+    # Property method with decorator
+    @property
+    def property_method(self):
+        ...`, skeleton)
+}
+
+func (s *PythonParserTestSuite) TestChunkSplitting() {
+	s.parser.SetMaxChunkTokens(40)
+	defer s.parser.SetMaxChunkTokens(0)
+
+	chunks := s.getChunks("python/classes.py")
+
+	chunk, exists := chunks["ClassWithMethods"]
+	s.Require().True(exists, "chunk %s not found", "ClassWithMethods")
+
+	// Comments stay with the members they describe
+	tests := []struct {
+		path      string
+		source    string
+		startLine int
+		endLine   int
+	}{
+		{
+			path: "ClassWithMethods#1",
+			source: `class ClassWithMethods:
+    value = -1
+
+    # Constructor method
+    def __init__(self):
+        self.value = 0`,
+			startLine: 7,
+			endLine:   12,
+		},
+		{
+			path: "ClassWithMethods#2",
+			source: `# Instance method
+    def method(self):
+        return self.value`,
+			startLine: 14,
+			endLine:   16,
+		},
+		{
+			path: "ClassWithMethods#3",
+			source: `# Property method with decorator
+    @property
+    def property_method(self):
+        return self.value * 2`,
+			startLine: 18,
+			endLine:   21,
+		},
+	}
+
+	s.Require().Len(chunk.Parts, len(tests))
+	for i, test := range tests {
+		s.Run(test.path, func() {
+			part := chunk.Parts[i]
+			s.Equal(test.path, part.Path)
+			s.Equal(test.source, part.Source)
+			s.Equal(test.startLine, int(part.StartLine))
+			s.Equal(test.endLine, int(part.EndLine))
+		})
+	}
+}
+
 func (s *PythonParserTestSuite) TearDownSuite() {
 	if s.parser != nil {
 		s.parser.Close()
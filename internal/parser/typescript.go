@@ -6,54 +6,88 @@ import (
 )
 
 var TypeScriptSpec = &LanguageSpec{
+	Language: "typescript",
 	NamedChunks: map[string]NamedChunkExtractor{
 		"function_declaration": {
 			NameQuery: `(function_declaration name: (identifier) @name)`,
+			Name:      jsName,
+			Kind:      jsReactKind,
 		},
 		"function_signature": {
 			NameQuery: `(function_signature name: (identifier) @name)`,
+			Name:      jsName,
 		},
 		"generator_function_declaration": {
 			NameQuery: `(generator_function_declaration name: (identifier) @name)`,
+			Name:      jsName,
 		},
 		"class_declaration": {
 			NameQuery: `(class_declaration name: (type_identifier) @name)`,
+			Name:      jsName,
+			Kind:      jsReactKind,
 		},
 		"abstract_class_declaration": {
 			NameQuery: `(abstract_class_declaration name: (type_identifier) @name)`,
+			Name:      jsName,
 		},
 		"interface_declaration": {
 			NameQuery: `(interface_declaration name: (type_identifier) @name)`,
+			Name:      jsName,
 		},
 		"type_alias_declaration": {
 			NameQuery: `(type_alias_declaration name: (type_identifier) @name)`,
+			Name:      jsName,
 		},
 		"lexical_declaration": {
-			NameQuery: `(lexical_declaration (variable_declarator name: (identifier) @name))`,
+			NameQuery:    `(lexical_declaration (variable_declarator name: (identifier) @name))`,
+			Name:         jsName,
+			MembersQuery: `(lexical_declaration (variable_declarator value: ` + jsMethodsObject + `))`,
+			Kind:         jsReactKind,
 		},
 		"variable_declaration": {
-			NameQuery: `(variable_declaration (variable_declarator name: (identifier) @name))`,
+			NameQuery:    `(variable_declaration (variable_declarator name: (identifier) @name))`,
+			Name:         jsName,
+			MembersQuery: `(variable_declaration (variable_declarator value: ` + jsMethodsObject + `))`,
+			Kind:         jsReactKind,
+		},
+		// CommonJS exports, e.g., module.exports = { ... } & exports.foo = function () {}
+		"expression_statement": {
+			Name:         jsCommonJSName,
+			MembersQuery: `(expression_statement (assignment_expression right: ` + jsMethodsObject + `))`,
+		},
+		// Object literal members, e.g., getUser: async (id: string) => { ... }
+		"pair": {
+			NameQuery: `(pair key: [(property_identifier) @name (string (string_fragment) @name)])`,
+			Name:      jsName,
+			Skip:      jsNonFunctionPair,
 		},
 		"ambient_declaration": {
 			NameQuery: `(ambient_declaration (variable_declaration (variable_declarator name: (identifier) @name)))`,
+			Name:      jsName,
 		},
 		"enum_declaration": {
 			NameQuery: `(enum_declaration name: (identifier) @name)`,
+			Name:      jsName,
 		},
 		"module": {
 			NameQuery: `(module name: (identifier) @name)`,
+			Name:      jsName,
 		},
 		"method_definition": {
 			NameQuery: `(method_definition name: (property_identifier) @name)`,
+			Name:      jsName,
 		},
 		"public_field_definition": {
 			NameQuery: `(public_field_definition name: (property_identifier) @name)`,
+			Name:      jsName,
 		},
 		"field_definition": {
 			NameQuery: `(field_definition name: (property_identifier) @name)`,
+			Name:      jsName,
 		},
 		"abstract_method_signature": {
 			NameQuery: `(abstract_method_signature name: (property_identifier) @name)`,
+			Name:      jsName,
 		},
 	},
 	ExtractChildrenIn: []string{
@@ -68,10 +102,12 @@ var TypeScriptSpec = &LanguageSpec{
 		"import_statement",
 		"import_alias",
 		// Skip punctuation and keyword tokens
-		"{", "}", ";",
+		"{", "}", ";", ",",
 		"class", "abstract", "extends", "implements",
 		// Skip identifier tokens (they're part of declarations)
 		"type_identifier", "identifier",
+		// Skip object literal members that only reference other declarations
+		"shorthand_property_identifier", "spread_element",
 		// Skip type parameters and clauses
 		"type_parameters", "class_heritage",
 		// Skip decorators as separate chunks (they're folded into definitions)
@@ -80,6 +116,17 @@ var TypeScriptSpec = &LanguageSpec{
 		"class_body",
 		"export_statement",
 	},
+	ImportTypes: []string{"import_statement", "import_alias"},
+	BodyQuery: `
+		[
+			(function_declaration body: (statement_block) @body)
+			(generator_function_declaration body: (statement_block) @body)
+			(function_expression body: (statement_block) @body)
+			(generator_function body: (statement_block) @body)
+			(arrow_function body: (statement_block) @body)
+			(method_definition body: (statement_block) @body)
+		]`,
+	ElidedBody: "{ ... }",
 	FileTypeRules: []FileTypeRule{
 		{Pattern: "**/*.test.ts", Type: FileTypeTests},
 		{Pattern: "**/*.test.tsx", Type: FileTypeTests},
@@ -101,3 +148,16 @@ func NewTypeScriptParser(workspaceRoot string) (*Parser, error) {
 		spec:          TypeScriptSpec,
 	}, nil
 }
+
+// NewTSXParser parses TypeScript with JSX, e.g., React components in .tsx files,
+// which the TypeScript grammar can't since <T>x is a type assertion there
+func NewTSXParser(workspaceRoot string) (*Parser, error) {
+	parser := tree_sitter.NewParser()
+	parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_typescript.LanguageTSX()))
+
+	return &Parser{
+		workspaceRoot: workspaceRoot,
+		parser:        parser,
+		spec:          TypeScriptSpec,
+	}, nil
+}
@@ -1,9 +1,12 @@
 package parser_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -33,7 +36,7 @@ func (s *TypeScriptParserTestSuite) TestFunctionParsing() {
 		{
 			name:    "Simple Function",
 			path:    "simple_function",
-			summary: "function simple_function(): string {",
+			summary: "Test file for TypeScript function definitions Simple function declaration with type...",
 			source: `// Test file for TypeScript function definitions
 
 // Simple function declaration with type annotations
@@ -46,7 +49,7 @@ function simple_function(): string {
 		{
 			name:    "Function With Params",
 			path:    "function_with_params",
-			summary: "function function_with_params(a: number, b: number): number {",
+			summary: "Function with typed parameters and return value",
 			source: `// Function with typed parameters and return value
 function function_with_params(a: number, b: number): number {
     return a + b;
@@ -57,7 +60,7 @@ function function_with_params(a: number, b: number): number {
 		{
 			name:    "Arrow Function",
 			path:    "arrow_function",
-			summary: "const arrow_function = (): string => {",
+			summary: "Arrow function assigned to variable with types",
 			source: `// Arrow function assigned to variable with types
 const arrow_function = (): string => {
     return "arrow";
@@ -68,7 +71,7 @@ const arrow_function = (): string => {
 		{
 			name:    "Arrow With Params",
 			path:    "arrow_with_params",
-			summary: "const arrow_with_params = (x: number, y: number): number => {",
+			summary: "Arrow function with typed parameters",
 			source: `// Arrow function with typed parameters
 const arrow_with_params = (x: number, y: number): number => {
     return x * y;
@@ -79,7 +82,7 @@ const arrow_with_params = (x: number, y: number): number => {
 		{
 			name:    "Function Expression",
 			path:    "function_expression",
-			summary: "const function_expression = function(a: string, b: string): string {",
+			summary: "Function expression assigned to variable with types",
 			source: `// Function expression assigned to variable with types
 const function_expression = function(a: string, b: string): string {
     return a + b;
@@ -90,7 +93,7 @@ const function_expression = function(a: string, b: string): string {
 		{
 			name:    "Named Function Expression",
 			path:    "named_function_expression",
-			summary: "const named_function_expression = function namedFn(value: any): string {",
+			summary: "Named function expression with types",
 			source: `// Named function expression with types
 const named_function_expression = function namedFn(value: any): string {
     return "named expression";
@@ -101,7 +104,7 @@ const named_function_expression = function namedFn(value: any): string {
 		{
 			name:    "Async Function",
 			path:    "async_function",
-			summary: "async function async_function(): Promise<string> {",
+			summary: "Async function with Promise return type",
 			source: `// Async function with Promise return type
 async function async_function(): Promise<string> {
     return "async result";
@@ -112,7 +115,7 @@ async function async_function(): Promise<string> {
 		{
 			name:    "Async Arrow",
 			path:    "async_arrow",
-			summary: "const async_arrow = async (data: string): Promise<string> => {",
+			summary: "Async arrow function with typed parameter",
 			source: `// Async arrow function with typed parameter
 const async_arrow = async (data: string): Promise<string> => {
     return "async arrow";
@@ -123,7 +126,7 @@ const async_arrow = async (data: string): Promise<string> => {
 		{
 			name:    "Generator Function",
 			path:    "generator_function",
-			summary: "function* generator_function(): Generator<number, void, unknown> {",
+			summary: "Generator function with typed yield",
 			source: `// Generator function with typed yield
 function* generator_function(): Generator<number, void, unknown> {
     yield 1;
@@ -135,7 +138,7 @@ function* generator_function(): Generator<number, void, unknown> {
 		{
 			name:    "Generic Function",
 			path:    "generic_function",
-			summary: "function generic_function<T>(value: T): T {",
+			summary: "Generic function",
 			source: `// Generic function
 function generic_function<T>(value: T): T {
     return value;
@@ -146,7 +149,7 @@ function generic_function<T>(value: T): T {
 		{
 			name:    "Optional Params",
 			path:    "optional_params",
-			summary: "function optional_params(required: string, optional?: number): string {",
+			summary: "Function with optional parameters",
 			source: `// Function with optional parameters
 function optional_params(required: string, optional?: number): string {
     return required + (optional || 0);
@@ -157,7 +160,7 @@ function optional_params(required: string, optional?: number): string {
 		{
 			name:    "Rest Params",
 			path:    "rest_params",
-			summary: "function rest_params(first: string, ...rest: number[]): string {",
+			summary: "Function with rest parameters",
 			source: `// Function with rest parameters
 function rest_params(first: string, ...rest: number[]): string {
     return first + rest.join(',');
@@ -168,7 +171,7 @@ function rest_params(first: string, ...rest: number[]): string {
 		{
 			name:    "Function Signature",
 			path:    "myFunc",
-			summary: "function myFunc(x: number): string;",
+			summary: "Function signature",
 			source: `// Function signature
 function myFunc(x: number): string;`,
 			startLine: 64,
@@ -177,7 +180,7 @@ function myFunc(x: number): string;`,
 		{
 			name:    "Exported Function",
 			path:    "exportedFunction",
-			summary: "function exportedFunction(input: string): boolean {",
+			summary: "Exported functions",
 			source: `// Exported functions
 export function exportedFunction(input: string): boolean {
     return input.length > 0;
@@ -188,7 +191,7 @@ export function exportedFunction(input: string): boolean {
 		{
 			name:    "Async Exported Function",
 			path:    "asyncExportedFunction",
-			summary: "async function asyncExportedFunction(data: any): Promise<void> {",
+			summary: "async exported function: async function asyncExportedFunction(data: any): Promis...",
 			source: `export async function asyncExportedFunction(data: any): Promise<void> {
     await Promise.resolve(data);
 }`,
@@ -198,7 +201,7 @@ export function exportedFunction(input: string): boolean {
 		{
 			name:    "Exported Default Function",
 			path:    "defaultExportedFunction",
-			summary: "function defaultExportedFunction(): string {",
+			summary: "default exported function: function defaultExportedFunction(): string",
 			source: `export default function defaultExportedFunction(): string {
     return "default";
 }`,
@@ -238,7 +241,7 @@ func (s *TypeScriptParserTestSuite) TestClassParsing() {
 		{
 			name:    "Simple Class",
 			path:    "SimpleClass",
-			summary: "class SimpleClass {",
+			summary: "Test file for TypeScript class definitions Simple class with no methods",
 			source: `// Test file for TypeScript class definitions
 
 // Simple class with no methods
@@ -250,7 +253,7 @@ class SimpleClass {
 		{
 			name:    "Class With Methods",
 			path:    "ClassWithMethods",
-			summary: "class ClassWithMethods {",
+			summary: "Class with typed constructor and methods",
 			source: `// Class with typed constructor and methods
 class ClassWithMethods {
     private value: number;
@@ -293,7 +296,7 @@ class ClassWithMethods {
 		{
 			name:    "Extended Class",
 			path:    "ExtendedClass",
-			summary: "class ExtendedClass<T> extends ClassWithMethods {",
+			summary: "Class with inheritance and generics",
 			source: `// Class with inheritance and generics
 class ExtendedClass<T> extends ClassWithMethods {
     private name: T;
@@ -319,7 +322,7 @@ class ExtendedClass<T> extends ClassWithMethods {
 		{
 			name:    "Abstract Class",
 			path:    "AbstractClass",
-			summary: "abstract class AbstractClass {",
+			summary: "Abstract class",
 			source: `// Abstract class
 abstract class AbstractClass {
     protected abstract process(): void;
@@ -334,7 +337,7 @@ abstract class AbstractClass {
 		{
 			name:    "Processable Interface",
 			path:    "Processable",
-			summary: "interface Processable {",
+			summary: "Interface implementation",
 			source: `// Interface implementation
 interface Processable {
     process(): void;
@@ -345,7 +348,7 @@ interface Processable {
 		{
 			name:    "Implemented Class",
 			path:    "ImplementedClass",
-			summary: "class ImplementedClass extends AbstractClass implements Processable {",
+			summary: "implemented class: class ImplementedClass extends AbstractClass implements Proce...",
 			source: `class ImplementedClass extends AbstractClass implements Processable {
     process(): void {
         console.log("processing");
@@ -357,7 +360,7 @@ interface Processable {
 		{
 			name:    "Config Class",
 			path:    "ConfigClass",
-			summary: "class ConfigClass {",
+			summary: "Class with readonly and optional properties",
 			source: `// Class with readonly and optional properties
 class ConfigClass {
     readonly id: string;
@@ -375,7 +378,7 @@ class ConfigClass {
 		{
 			name:    "Decorated Class",
 			path:    "BugReport",
-			summary: "@sealed",
+			summary: "Decorated",
 			source: `// Decorated
 
 @sealed
@@ -393,7 +396,7 @@ class BugReport {
 		{
 			name:    "Exported Class",
 			path:    "ExportedClass",
-			summary: "class ExportedClass {",
+			summary: "Exported classes",
 			source: `// Exported classes
 export class ExportedClass {
     private value: number;
@@ -412,7 +415,7 @@ export class ExportedClass {
 		{
 			name:    "Exported OpenRouterProvider",
 			path:    "OpenRouterProvider",
-			summary: "class OpenRouterProvider implements Processable {",
+			summary: "open router provider: class OpenRouterProvider implements Processable",
 			source: `export class OpenRouterProvider implements Processable {
     name = "OpenRouter";
 
@@ -426,7 +429,7 @@ export class ExportedClass {
 		{
 			name:    "Exported Default Class",
 			path:    "TutorPlugin",
-			summary: "class TutorPlugin {",
+			summary: "tutor plugin: class TutorPlugin",
 			source: `export default class TutorPlugin {
     private config: any;
 
@@ -441,7 +444,7 @@ export class ExportedClass {
 		{
 			name:      "ClassWithMethods::value",
 			path:      "ClassWithMethods::value",
-			summary:   "private value: number",
+			summary:   "value: private value: number",
 			source:    "private value: number",
 			startLine: 9,
 			endLine:   9,
@@ -449,7 +452,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::constructor",
 			path:    "ClassWithMethods::constructor",
-			summary: "constructor(value: number) {",
+			summary: "constructor: constructor(value: number)",
 			source: `constructor(value: number) {
         this.value = value;
     }`,
@@ -459,7 +462,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::getValue",
 			path:    "ClassWithMethods::getValue",
-			summary: "getValue(): number {",
+			summary: "Instance method with return type",
 			source: `// Instance method with return type
     getValue(): number {
         return this.value;
@@ -470,7 +473,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::setValue",
 			path:    "ClassWithMethods::setValue",
-			summary: "setValue(newValue: number): void {",
+			summary: "Setter method with typed parameter",
 			source: `// Setter method with typed parameter
     setValue(newValue: number): void {
         this.value = newValue;
@@ -481,7 +484,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::createDefault",
 			path:    "ClassWithMethods::createDefault",
-			summary: "static createDefault(): ClassWithMethods {",
+			summary: "Static method with return type",
 			source: `// Static method with return type
     static createDefault(): ClassWithMethods {
         return new ClassWithMethods(0);
@@ -492,7 +495,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::displayValue getter",
 			path:    "ClassWithMethods::displayValue",
-			summary: "get displayValue(): string {",
+			summary: "Getter method with return type",
 			source: `// Getter method with return type
     get displayValue(): string {
         return ` + "`Value: ${this.value}`" + `;
@@ -503,7 +506,7 @@ export class ExportedClass {
 		{
 			name:    "ClassWithMethods::displayValue setter",
 			path:    "ClassWithMethods::displayValue-2",
-			summary: "set displayValue(val: string) {",
+			summary: "Setter property",
 			source: `// Setter property
     set displayValue(val: string) {
         const match = val.match(/Value: (\d+)/);
@@ -518,7 +521,7 @@ export class ExportedClass {
 		{
 			name:      "ExtendedClass::name",
 			path:      "ExtendedClass::name",
-			summary:   "private name: T",
+			summary:   "name: private name: T",
 			source:    "private name: T",
 			startLine: 46,
 			endLine:   46,
@@ -526,7 +529,7 @@ export class ExportedClass {
 		{
 			name:    "ExtendedClass::constructor",
 			path:    "ExtendedClass::constructor",
-			summary: "constructor(value: number, name: T) {",
+			summary: "constructor: constructor(value: number, name: T)",
 			source: `constructor(value: number, name: T) {
         super(value);
         this.name = name;
@@ -537,7 +540,7 @@ export class ExportedClass {
 		{
 			name:    "ExtendedClass::getValue",
 			path:    "ExtendedClass::getValue",
-			summary: "getValue(): string {",
+			summary: "Override parent method with different return type",
 			source: `// Override parent method with different return type
     getValue(): string {
         return ` + "`${this.name}: ${super.getValue()}`" + `;
@@ -548,7 +551,7 @@ export class ExportedClass {
 		{
 			name:    "ExtendedClass::getName",
 			path:    "ExtendedClass::getName",
-			summary: "getName(): T {",
+			summary: "New method with generic return",
 			source: `// New method with generic return
     getName(): T {
         return this.name;
@@ -560,7 +563,7 @@ export class ExportedClass {
 		{
 			name:      "AbstractClass::process",
 			path:      "AbstractClass::process",
-			summary:   "protected abstract process(): void",
+			summary:   "process: protected abstract process(): void",
 			source:    "protected abstract process(): void",
 			startLine: 66,
 			endLine:   66,
@@ -568,7 +571,7 @@ export class ExportedClass {
 		{
 			name:    "AbstractClass::execute",
 			path:    "AbstractClass::execute",
-			summary: "public execute(): void {",
+			summary: "execute: public execute(): void",
 			source: `public execute(): void {
         this.process();
     }`,
@@ -579,7 +582,7 @@ export class ExportedClass {
 		{
 			name:    "ImplementedClass::process",
 			path:    "ImplementedClass::process",
-			summary: "process(): void {",
+			summary: "process: process(): void",
 			source: `process(): void {
         console.log("processing");
     }`,
@@ -590,7 +593,7 @@ export class ExportedClass {
 		{
 			name:      "ConfigClass::id",
 			path:      "ConfigClass::id",
-			summary:   "readonly id: string",
+			summary:   "id: readonly id: string",
 			source:    "readonly id: string",
 			startLine: 86,
 			endLine:   86,
@@ -598,7 +601,7 @@ export class ExportedClass {
 		{
 			name:      "ConfigClass::name",
 			path:      "ConfigClass::name",
-			summary:   "name?: string",
+			summary:   "name: name?: string",
 			source:    "name?: string",
 			startLine: 87,
 			endLine:   87,
@@ -606,7 +609,7 @@ export class ExportedClass {
 		{
 			name:      "ConfigClass::_config",
 			path:      "ConfigClass::_config",
-			summary:   "private _config: Record<string, any> = {}",
+			summary:   "config: private _config: Record<string, any> = {}",
 			source:    "private _config: Record<string, any> = {}",
 			startLine: 88,
 			endLine:   88,
@@ -614,7 +617,7 @@ export class ExportedClass {
 		{
 			name:    "ConfigClass::constructor",
 			path:    "ConfigClass::constructor",
-			summary: "constructor(id: string, name?: string) {",
+			summary: "constructor: constructor(id: string, name?: string)",
 			source: `constructor(id: string, name?: string) {
         this.id = id;
         this.name = name;
@@ -626,7 +629,7 @@ export class ExportedClass {
 		{
 			name:      "BugReport::type",
 			path:      "BugReport::type",
-			summary:   "type = \"report\"",
+			summary:   `type: type = "report"`,
 			source:    `type = "report"`,
 			startLine: 100,
 			endLine:   100,
@@ -634,7 +637,7 @@ export class ExportedClass {
 		{
 			name:      "BugReport::title",
 			path:      "BugReport::title",
-			summary:   "title: string",
+			summary:   "title: title: string",
 			source:    "title: string",
 			startLine: 101,
 			endLine:   101,
@@ -642,7 +645,7 @@ export class ExportedClass {
 		{
 			name:    "BugReport::constructor",
 			path:    "BugReport::constructor",
-			summary: "constructor(t: string) {",
+			summary: "constructor: constructor(t: string)",
 			source: `constructor(t: string) {
     this.title = t;
   }`,
@@ -653,7 +656,7 @@ export class ExportedClass {
 		{
 			name:      "ExportedClass::value",
 			path:      "ExportedClass::value",
-			summary:   "private value: number",
+			summary:   "value: private value: number",
 			source:    "private value: number",
 			startLine: 110,
 			endLine:   110,
@@ -661,7 +664,7 @@ export class ExportedClass {
 		{
 			name:    "ExportedClass::constructor",
 			path:    "ExportedClass::constructor",
-			summary: "constructor(value: number) {",
+			summary: "constructor: constructor(value: number)",
 			source: `constructor(value: number) {
         this.value = value;
     }`,
@@ -671,7 +674,7 @@ export class ExportedClass {
 		{
 			name:    "ExportedClass::getValue",
 			path:    "ExportedClass::getValue",
-			summary: "getValue(): number {",
+			summary: "get value: getValue(): number",
 			source: `getValue(): number {
         return this.value;
     }`,
@@ -682,7 +685,7 @@ export class ExportedClass {
 		{
 			name:      "OpenRouterProvider::name",
 			path:      "OpenRouterProvider::name",
-			summary:   "name = \"OpenRouter\"",
+			summary:   `name: name = "OpenRouter"`,
 			source:    `name = "OpenRouter"`,
 			startLine: 122,
 			endLine:   122,
@@ -690,7 +693,7 @@ export class ExportedClass {
 		{
 			name:    "OpenRouterProvider::process",
 			path:    "OpenRouterProvider::process",
-			summary: "process(): void {",
+			summary: "process: process(): void",
 			source: `process(): void {
         console.log("processing");
     }`,
@@ -701,7 +704,7 @@ export class ExportedClass {
 		{
 			name:      "TutorPlugin::config",
 			path:      "TutorPlugin::config",
-			summary:   "private config: any",
+			summary:   "config: private config: any",
 			source:    "private config: any",
 			startLine: 130,
 			endLine:   130,
@@ -709,7 +712,7 @@ export class ExportedClass {
 		{
 			name:    "TutorPlugin::constructor",
 			path:    "TutorPlugin::constructor",
-			summary: "constructor(config: any) {",
+			summary: "constructor: constructor(config: any)",
 			source: `constructor(config: any) {
         this.config = config;
     }`,
@@ -749,7 +752,7 @@ func (s *TypeScriptParserTestSuite) TestInterfaceParsing() {
 		{
 			name:    "Simple Interface",
 			path:    "SimpleInterface",
-			summary: "interface SimpleInterface {",
+			summary: "Test file for TypeScript interface definitions Simple interface",
 			source: `// Test file for TypeScript interface definitions
 
 // Simple interface
@@ -763,7 +766,7 @@ interface SimpleInterface {
 		{
 			name:    "Optional Interface",
 			path:    "OptionalInterface",
-			summary: "interface OptionalInterface {",
+			summary: "Interface with optional properties",
 			source: `// Interface with optional properties
 interface OptionalInterface {
     required: string;
@@ -776,7 +779,7 @@ interface OptionalInterface {
 		{
 			name:    "Method Interface",
 			path:    "MethodInterface",
-			summary: "interface MethodInterface {",
+			summary: "Interface with method signatures",
 			source: `// Interface with method signatures
 interface MethodInterface {
     getName(): string;
@@ -789,7 +792,7 @@ interface MethodInterface {
 		{
 			name:    "Generic Interface",
 			path:    "GenericInterface",
-			summary: "interface GenericInterface<T, U = string> {",
+			summary: "Generic interface",
 			source: `// Generic interface
 interface GenericInterface<T, U = string> {
     data: T;
@@ -802,7 +805,7 @@ interface GenericInterface<T, U = string> {
 		{
 			name:    "Extended Interface",
 			path:    "ExtendedInterface",
-			summary: "interface ExtendedInterface extends SimpleInterface, MethodInterface {",
+			summary: "Extending interfaces",
 			source: `// Extending interfaces
 interface ExtendedInterface extends SimpleInterface, MethodInterface {
     category: string;
@@ -814,7 +817,7 @@ interface ExtendedInterface extends SimpleInterface, MethodInterface {
 		{
 			name:    "Indexed Interface",
 			path:    "IndexedInterface",
-			summary: "interface IndexedInterface {",
+			summary: "Interface with index signature",
 			source: `// Interface with index signature
 interface IndexedInterface {
     [key: string]: any;
@@ -826,7 +829,7 @@ interface IndexedInterface {
 		{
 			name:    "Callable Interface",
 			path:    "CallableInterface",
-			summary: "interface CallableInterface {",
+			summary: "Interface with call signature",
 			source: `// Interface with call signature
 interface CallableInterface {
     (input: string): boolean;
@@ -838,7 +841,7 @@ interface CallableInterface {
 		{
 			name:    "Constructable Interface",
 			path:    "ConstructableInterface",
-			summary: "interface ConstructableInterface {",
+			summary: "Interface with construct signature",
 			source: `// Interface with construct signature
 interface ConstructableInterface {
     new (value: string): { result: string };
@@ -849,7 +852,7 @@ interface ConstructableInterface {
 		{
 			name:    "Namespace Interface",
 			path:    "5a8ecd1335d8d887",
-			summary: "namespace Interfaces {",
+			summary: "Namespace interface",
 			source: `// Namespace interface
 namespace Interfaces {
     export interface NamespacedInterface {
@@ -862,7 +865,7 @@ namespace Interfaces {
 		{
 			name:    "Merged Interface I",
 			path:    "MergedInterface",
-			summary: "interface MergedInterface {",
+			summary: "Merged interface declarations",
 			source: `// Merged interface declarations
 interface MergedInterface {
     first: string;
@@ -873,7 +876,7 @@ interface MergedInterface {
 		{
 			name:    "Merged Interface II",
 			path:    "MergedInterface-2",
-			summary: "interface MergedInterface {",
+			summary: "merged interface: interface MergedInterface",
 			source: `interface MergedInterface {
     second: number;
 }`,
@@ -883,7 +886,7 @@ interface MergedInterface {
 		{
 			name:    "Exported Interface",
 			path:    "ExportedInterface",
-			summary: "interface ExportedInterface {",
+			summary: "Exported interfaces",
 			source: `// Exported interfaces
 export interface ExportedInterface {
     id: string;
@@ -895,7 +898,7 @@ export interface ExportedInterface {
 		{
 			name:    "Exported LLMProvider",
 			path:    "LLMProvider",
-			summary: "interface LLMProvider {",
+			summary: "llm provider: interface LLMProvider",
 			source: `export interface LLMProvider {
     name: string;
     generate(prompt: string): Promise<string>;
@@ -936,7 +939,7 @@ func (s *TypeScriptParserTestSuite) TestTypeParsing() {
 		{
 			name:    "Simple Type",
 			path:    "SimpleType",
-			summary: "type SimpleType = string;",
+			summary: "Test file for TypeScript type definitions Simple type alias",
 			source: `// Test file for TypeScript type definitions
 
 // Simple type alias
@@ -947,7 +950,7 @@ type SimpleType = string;`,
 		{
 			name:    "Union Type",
 			path:    "UnionType",
-			summary: "type UnionType = string | number | boolean;",
+			summary: "Union type",
 			source: `// Union type
 type UnionType = string | number | boolean;`,
 			startLine: 6,
@@ -956,7 +959,7 @@ type UnionType = string | number | boolean;`,
 		{
 			name:    "Intersection Type",
 			path:    "IntersectionType",
-			summary: "type IntersectionType = { name: string } & { age: number };",
+			summary: "Intersection type",
 			source: `// Intersection type
 type IntersectionType = { name: string } & { age: number };`,
 			startLine: 9,
@@ -965,7 +968,7 @@ type IntersectionType = { name: string } & { age: number };`,
 		{
 			name:    "Generic Type",
 			path:    "GenericType",
-			summary: "type GenericType<T> = {",
+			summary: "Generic type alias",
 			source: `// Generic type alias
 type GenericType<T> = {
     value: T;
@@ -977,7 +980,7 @@ type GenericType<T> = {
 		{
 			name:    "Function Type",
 			path:    "FunctionType",
-			summary: "type FunctionType = (input: string) => boolean;",
+			summary: "Function type alias",
 			source: `// Function type alias
 type FunctionType = (input: string) => boolean;`,
 			startLine: 18,
@@ -986,7 +989,7 @@ type FunctionType = (input: string) => boolean;`,
 		{
 			name:    "Overloaded Function",
 			path:    "OverloadedFunction",
-			summary: "type OverloadedFunction = {",
+			summary: "Complex function type with overloads",
 			source: `// Complex function type with overloads
 type OverloadedFunction = {
     (input: string): string;
@@ -999,7 +1002,7 @@ type OverloadedFunction = {
 		{
 			name:    "Object Type",
 			path:    "ObjectType",
-			summary: "type ObjectType = {",
+			summary: "Object type alias",
 			source: `// Object type alias
 type ObjectType = {
     readonly id: string;
@@ -1013,7 +1016,7 @@ type ObjectType = {
 		{
 			name:    "String Array",
 			path:    "StringArray",
-			summary: "type StringArray = string[];",
+			summary: "Array type aliases",
 			source: `// Array type aliases
 type StringArray = string[];`,
 			startLine: 36,
@@ -1022,7 +1025,7 @@ type StringArray = string[];`,
 		{
 			name:      "Number Tuple",
 			path:      "NumberTuple",
-			summary:   "type NumberTuple = [number, number, string?];",
+			summary:   "number tuple: type NumberTuple = [number, number, string?];",
 			source:    `type NumberTuple = [number, number, string?];`,
 			startLine: 38,
 			endLine:   38,
@@ -1030,7 +1033,7 @@ type StringArray = string[];`,
 		{
 			name:    "Conditional Type",
 			path:    "ConditionalType",
-			summary: "type ConditionalType<T> = T extends string ? string[] : T[];",
+			summary: "Conditional type",
 			source: `// Conditional type
 type ConditionalType<T> = T extends string ? string[] : T[];`,
 			startLine: 40,
@@ -1039,7 +1042,7 @@ type ConditionalType<T> = T extends string ? string[] : T[];`,
 		{
 			name:    "Mapped Type",
 			path:    "MappedType",
-			summary: "type MappedType<T> = {",
+			summary: "Mapped type",
 			source: `// Mapped type
 type MappedType<T> = {
     [K in keyof T]: T[K] | null;
@@ -1050,7 +1053,7 @@ type MappedType<T> = {
 		{
 			name:    "Template Type",
 			path:    "TemplateType",
-			summary: "type TemplateType = `prefix_${string}_suffix`;",
+			summary: "Template literal type",
 			source: `// Template literal type
 type TemplateType = ` + "`prefix_${string}_suffix`;",
 			startLine: 48,
@@ -1059,7 +1062,7 @@ type TemplateType = ` + "`prefix_${string}_suffix`;",
 		{
 			name:    "Partial User",
 			path:    "PartialUser",
-			summary: "type PartialUser = Partial<{ name: string; age: number }>;",
+			summary: "Utility type usage",
 			source: `// Utility type usage
 type PartialUser = Partial<{ name: string; age: number }>;`,
 			startLine: 51,
@@ -1068,7 +1071,7 @@ type PartialUser = Partial<{ name: string; age: number }>;`,
 		{
 			name:      "Required User",
 			path:      "RequiredUser",
-			summary:   "type RequiredUser = Required<{ name?: string; age?: number }>;",
+			summary:   "required user: type RequiredUser = Required<{ name?: string; age?: number }>;",
 			source:    `type RequiredUser = Required<{ name?: string; age?: number }>;`,
 			startLine: 53,
 			endLine:   53,
@@ -1076,7 +1079,7 @@ type PartialUser = Partial<{ name: string; age: number }>;`,
 		{
 			name:    "Status Type",
 			path:    "StatusType",
-			summary: "type StatusType = 'pending' | 'completed' | 'failed';",
+			summary: "Enum-like type",
 			source: `// Enum-like type
 type StatusType = 'pending' | 'completed' | 'failed';`,
 			startLine: 55,
@@ -1085,7 +1088,7 @@ type StatusType = 'pending' | 'completed' | 'failed';`,
 		{
 			name:    "Recursive Type",
 			path:    "RecursiveType",
-			summary: "type RecursiveType<T> = T | RecursiveType<T>[];",
+			summary: "Recursive type",
 			source: `// Recursive type
 type RecursiveType<T> = T | RecursiveType<T>[];`,
 			startLine: 58,
@@ -1094,7 +1097,7 @@ type RecursiveType<T> = T | RecursiveType<T>[];`,
 		{
 			name:    "Constrained Type",
 			path:    "ConstrainedType",
-			summary: "type ConstrainedType<T extends Record<string, any>> = {",
+			summary: "Type with generic constraints",
 			source: `// Type with generic constraints
 type ConstrainedType<T extends Record<string, any>> = {
     data: T;
@@ -1106,7 +1109,7 @@ type ConstrainedType<T extends Record<string, any>> = {
 		{
 			name:    "Exported Type",
 			path:    "ExportedType",
-			summary: "type ExportedType = string | number;",
+			summary: "Exported types",
 			source: `// Exported types
 export type ExportedType = string | number;`,
 			startLine: 67,
@@ -1115,7 +1118,7 @@ export type ExportedType = string | number;`,
 		{
 			name:    "Exported ConfigOptions",
 			path:    "ConfigOptions",
-			summary: "type ConfigOptions = {",
+			summary: "config options: type ConfigOptions =",
 			source: `export type ConfigOptions = {
     enabled: boolean;
     timeout: number;
@@ -1156,7 +1159,7 @@ func (s *TypeScriptParserTestSuite) TestEnumParsing() {
 		{
 			name:    "Simple Enum",
 			path:    "SimpleEnum",
-			summary: "enum SimpleEnum {",
+			summary: "Test file for TypeScript enum definitions Simple numeric enum",
 			source: `// Test file for TypeScript enum definitions
 
 // Simple numeric enum
@@ -1171,7 +1174,7 @@ enum SimpleEnum {
 		{
 			name:    "String Enum",
 			path:    "StringEnum",
-			summary: "enum StringEnum {",
+			summary: "String enum",
 			source: `// String enum
 enum StringEnum {
     Red = "red",
@@ -1184,7 +1187,7 @@ enum StringEnum {
 		{
 			name:    "Mixed Enum",
 			path:    "MixedEnum",
-			summary: "enum MixedEnum {",
+			summary: "Mixed enum",
 			source: `// Mixed enum
 enum MixedEnum {
     None = 0,
@@ -1199,7 +1202,7 @@ enum MixedEnum {
 		{
 			name:    "Computed Enum",
 			path:    "ComputedEnum",
-			summary: "enum ComputedEnum {",
+			summary: "Computed enum",
 			source: `// Computed enum
 enum ComputedEnum {
     Base = 1,
@@ -1212,7 +1215,7 @@ enum ComputedEnum {
 		{
 			name:    "Const Enum",
 			path:    "ConstEnum",
-			summary: "const enum ConstEnum {",
+			summary: "Const enum",
 			source: `// Const enum
 const enum ConstEnum {
     Tiny = 1,
@@ -1226,7 +1229,7 @@ const enum ConstEnum {
 		{
 			name:    "Status Enum",
 			path:    "StatusEnum",
-			summary: "enum StatusEnum {",
+			summary: "Enum with explicit values",
 			source: `// Enum with explicit values
 enum StatusEnum {
     Pending = "PENDING",
@@ -1240,7 +1243,7 @@ enum StatusEnum {
 		{
 			name:    "Bidirectional Enum",
 			path:    "BiDirectionalEnum",
-			summary: "enum BiDirectionalEnum {",
+			summary: "Reverse mapped enum",
 			source: `// Reverse mapped enum
 enum BiDirectionalEnum {
     Up = "UP",
@@ -1254,7 +1257,7 @@ enum BiDirectionalEnum {
 		{
 			name:    "Enum Namespace",
 			path:    "643245017f52983b",
-			summary: "namespace EnumNamespace {",
+			summary: "Enum in namespace",
 			source: `// Enum in namespace
 namespace EnumNamespace {
     export enum NestedEnum {
@@ -1268,7 +1271,7 @@ namespace EnumNamespace {
 		{
 			name:    "Exported Enum",
 			path:    "ExportedEnum",
-			summary: "enum ExportedEnum {",
+			summary: "Exported enums",
 			source: `// Exported enums
 export enum ExportedEnum {
     Alpha = "alpha",
@@ -1281,7 +1284,7 @@ export enum ExportedEnum {
 		{
 			name:    "Exported Status",
 			path:    "Status",
-			summary: "enum Status {",
+			summary: "status: enum Status",
 			source: `export enum Status {
     Pending = "pending",
     Active = "active",
@@ -1323,7 +1326,7 @@ func (s *TypeScriptParserTestSuite) TestNamespaceParsing() {
 		{
 			name:    "Simple Namespace",
 			path:    "843d1d59a469ba2e",
-			summary: "namespace SimpleNamespace {",
+			summary: "Test file for TypeScript namespace definitions Simple namespace",
 			source: `// Test file for TypeScript namespace definitions
 
 // Simple namespace
@@ -1339,7 +1342,7 @@ namespace SimpleNamespace {
 		{
 			name:    "Outer Namespace",
 			path:    "2888478eb6290af",
-			summary: "namespace OuterNamespace {",
+			summary: "Nested namespaces",
 			source: `// Nested namespaces
 namespace OuterNamespace {
     export namespace InnerNamespace {
@@ -1359,7 +1362,7 @@ namespace OuterNamespace {
 		{
 			name:    "Utility Namespace",
 			path:    "ab6ebc6c6bfe2530",
-			summary: "namespace UtilityNamespace {",
+			summary: "Namespace with classes and interfaces",
 			source: `// Namespace with classes and interfaces
 namespace UtilityNamespace {
     export interface Logger {
@@ -1382,7 +1385,7 @@ namespace UtilityNamespace {
 		{
 			name:    "External Library",
 			path:    "e55a503d7227a55b",
-			summary: "declare namespace ExternalLibrary {",
+			summary: "Module declaration (ambient namespace)",
 			source: `// Module declaration (ambient namespace)
 declare namespace ExternalLibrary {
     interface Options {
@@ -1397,7 +1400,7 @@ declare namespace ExternalLibrary {
 		{
 			name:    "Global Namespace",
 			path:    "25f16e2c0e5708b4",
-			summary: "declare global {",
+			summary: "Global augmentation",
 			source: `// Global augmentation
 declare global {
     namespace NodeJS {
@@ -1412,7 +1415,7 @@ declare global {
 		{
 			name:    "Merged Namespace 1",
 			path:    "68f0b782ae122c7",
-			summary: "namespace MergedNamespace {",
+			summary: "Namespace merging",
 			source: `// Namespace merging
 namespace MergedNamespace {
     export const first = "first";
@@ -1433,7 +1436,7 @@ namespace MergedNamespace {
 		{
 			name:    "Generic Namespace",
 			path:    "ac16ab1273e542c9",
-			summary: "namespace GenericNamespace {",
+			summary: "Namespace with generics",
 			source: `// Namespace with generics
 namespace GenericNamespace {
     export interface Container<T> {
@@ -1481,7 +1484,7 @@ func (s *TypeScriptParserTestSuite) TestTestFileParsing() {
 		{
 			name:      "File Comment",
 			path:      "5b931df60944a870",
-			summary:   "// Test file for TypeScript test patterns",
+			summary:   "Test file for TypeScript test patterns",
 			source:    `// Test file for TypeScript test patterns`,
 			startLine: 1,
 			endLine:   1,
@@ -1490,7 +1493,7 @@ func (s *TypeScriptParserTestSuite) TestTestFileParsing() {
 		{
 			name:    "Test Simple Function",
 			path:    "test_simple_function",
-			summary: "function test_simple_function(): void {",
+			summary: "Simple test function",
 			source: `// Simple test function
 function test_simple_function(): void {
     expect(true).toBe(true);
@@ -1502,7 +1505,7 @@ function test_simple_function(): void {
 		{
 			name:    "Test Sample Class",
 			path:    "TestSample",
-			summary: "class TestSample {",
+			summary: "Test class with typed methods",
 			source: `// Test class with typed methods
 class TestSample {
     test_method(): void {
@@ -1526,7 +1529,7 @@ class TestSample {
 		{
 			name:    "Sample Test Suite",
 			path:    "39749806ee9100df",
-			summary: "describe('Sample test suite', () => {",
+			summary: "Jest-style test suites with types",
 			source: `// Jest-style test suites with types
 describe('Sample test suite', () => {
     it('should pass basic test', () => {
@@ -1554,7 +1557,7 @@ describe('Sample test suite', () => {
 		{
 			name:    "Test Data Interface",
 			path:    "TestData",
-			summary: "interface TestData {",
+			summary: "Interface for test data",
 			source: `// Interface for test data
 interface TestData {
     input: string;
@@ -1567,7 +1570,7 @@ interface TestData {
 		{
 			name:    "Interface Test Suite",
 			path:    "609eeb753c9224f8",
-			summary: "describe('Interface test suite', () => {",
+			summary: "Test with interface",
 			source: `// Test with interface
 describe('Interface test suite', () => {
     const testCases: TestData[] = [
@@ -1605,6 +1608,35 @@ describe('Interface test suite', () => {
 	}
 }
 
+func (s *TypeScriptParserTestSuite) TestSkeletons() {
+	file, err := s.parser.Chunk("typescript/classes.ts")
+	s.Require().NoError(err)
+
+	skeleton, err := s.parser.Skeleton(file, "ClassWithMethods")
+	s.Require().NoError(err)
+	s.Equal(`// Class with typed constructor and methods
+class ClassWithMethods {
+    private value: number;
+
+    constructor(value: number) { ... }
+
+    // Instance method with return type
+    getValue(): number { ... }
+
+    // Setter method with typed parameter
+    setValue(newValue: number): void { ... }
+
+    // Static method with return type
+    static createDefault(): ClassWithMethods { ... }
+
+    // Getter method with return type
+    get displayValue(): string { ... }
+
+    // Setter property
+    set displayValue(val: string) { ... }
+}`, skeleton)
+}
+
 func (s *TypeScriptParserTestSuite) TearDownSuite() {
 	if s.parser != nil {
 		s.parser.Close()
@@ -1625,7 +1657,7 @@ func (s *TypeScriptParserTestSuite) TestVariableParsing() {
 		{
 			name:    "Simple Var",
 			path:    "simple_var",
-			summary: `var simple_var: string = "hello";`,
+			summary: "Test file for TypeScript variable declarations var declarations with types",
 			source: `// Test file for TypeScript variable declarations
 
 // var declarations with types
@@ -1636,7 +1668,7 @@ var simple_var: string = "hello";`,
 		{
 			name:      "Typed Number",
 			path:      "typed_number",
-			summary:   `var typed_number: number = 42;`,
+			summary:   "typed number: var typed_number: number = 42;",
 			source:    `var typed_number: number = 42;`,
 			startLine: 5,
 			endLine:   5,
@@ -1644,7 +1676,7 @@ var simple_var: string = "hello";`,
 		{
 			name:      "Inferred Var",
 			path:      "inferred_var",
-			summary:   `var inferred_var = "inferred";`,
+			summary:   `inferred var: var inferred_var = "inferred";`,
 			source:    `var inferred_var = "inferred";`,
 			startLine: 6,
 			endLine:   6,
@@ -1652,7 +1684,7 @@ var simple_var: string = "hello";`,
 		{
 			name:    "Simple Let",
 			path:    "simple_let",
-			summary: `let simple_let: string = "world";`,
+			summary: "let declarations with types",
 			source: `// let declarations with types
 let simple_let: string = "world";`,
 			startLine: 8,
@@ -1661,7 +1693,7 @@ let simple_let: string = "world";`,
 		{
 			name:      "Array Let",
 			path:      "array_let",
-			summary:   `let array_let: number[] = [1, 2, 3];`,
+			summary:   "array let: let array_let: number[] = [1, 2, 3];",
 			source:    `let array_let: number[] = [1, 2, 3];`,
 			startLine: 10,
 			endLine:   10,
@@ -1669,7 +1701,7 @@ let simple_let: string = "world";`,
 		{
 			name:      "Tuple Let",
 			path:      "tuple_let",
-			summary:   `let tuple_let: [string, number] = ["test", 42];`,
+			summary:   `tuple let: let tuple_let: [string, number] = ["test", 42];`,
 			source:    `let tuple_let: [string, number] = ["test", 42];`,
 			startLine: 11,
 			endLine:   11,
@@ -1677,7 +1709,7 @@ let simple_let: string = "world";`,
 		{
 			name:    "Simple Const",
 			path:    "simple_const",
-			summary: `const simple_const: string = "constant";`,
+			summary: "const declarations with types",
 			source: `// const declarations with types
 const simple_const: string = "constant";`,
 			startLine: 13,
@@ -1686,7 +1718,7 @@ const simple_const: string = "constant";`,
 		{
 			name:    "Object Const",
 			path:    "object_const",
-			summary: `const object_const: { name: string; value: number } = {`,
+			summary: "object const: const object_const: { name: string; value: number } =",
 			source: `const object_const: { name: string; value: number } = {
     name: "test",
     value: 123
@@ -1697,7 +1729,7 @@ const simple_const: string = "constant";`,
 		{
 			name:    "Arrow Function Const",
 			path:    "arrow_function_const",
-			summary: `const arrow_function_const = (x: number): number => x * 2;`,
+			summary: "Arrow function with types assigned to variables",
 			source: `// Arrow function with types assigned to variables
 const arrow_function_const = (x: number): number => x * 2;`,
 			startLine: 20,
@@ -1706,7 +1738,7 @@ const arrow_function_const = (x: number): number => x * 2;`,
 		{
 			name:    "Function Expression",
 			path:    "function_expression",
-			summary: `const function_expression = function(a: string, b: string): string {`,
+			summary: "Function expressions with types assigned to variables",
 			source: `// Function expressions with types assigned to variables
 const function_expression = function(a: string, b: string): string {
     return a + b;
@@ -1717,7 +1749,7 @@ const function_expression = function(a: string, b: string): string {
 		{
 			name:    "Named Function Expression",
 			path:    "named_function_expression",
-			summary: `const named_function_expression = function calculator(x: number, y: number): number...`,
+			summary: "named function expression: const named_function_expression = function calculator(x:...",
 			source: `const named_function_expression = function calculator(x: number, y: number): number {
     return x - y;
 };`,
@@ -1727,7 +1759,7 @@ const function_expression = function(a: string, b: string): string {
 		{
 			name:    "Simple Arrow",
 			path:    "simple_arrow",
-			summary: `const simple_arrow = (): string => "simple";`,
+			summary: "Arrow functions with different type syntaxes",
 			source: `// Arrow functions with different type syntaxes
 const simple_arrow = (): string => "simple";`,
 			startLine: 32,
@@ -1736,15 +1768,15 @@ const simple_arrow = (): string => "simple";`,
 		{
 			name:      "Arrow With Params",
 			path:      "arrow_with_params",
-			summary:   `const arrow_with_params = (a: number, b: number): number => a + b;`,
+			summary:   "arrow with params: const arrow_with_params = (a: number, b: number): number => a...",
 			source:    `const arrow_with_params = (a: number, b: number): number => a + b;`,
 			startLine: 34,
 			endLine:   34,
 		},
 		{
 			name:    "Arrow With Body",
-			path:    "767114ac2a5c8a3f",
-			summary: `const arrow_with_body = (x: number): number => {`,
+			path:    "arrow_with_body",
+			summary: "arrow with body: const arrow_with_body = (x: number): number =>",
 			source: `const arrow_with_body = (x: number): number => {
     const result: number = x * 2;
     return result;
@@ -1754,8 +1786,8 @@ const simple_arrow = (): string => "simple";`,
 		},
 		{
 			name:    "Async Arrow Func",
-			path:    "b17a6b2599fdca36",
-			summary: `const async_arrow_func = async (data: string): Promise<Response> => {`,
+			path:    "async_arrow_func",
+			summary: "Async arrow function with types",
 			source: `// Async arrow function with types
 const async_arrow_func = async (data: string): Promise<Response> => {
     const response: Response = await fetch(data);
@@ -1767,7 +1799,7 @@ const async_arrow_func = async (data: string): Promise<Response> => {
 		{
 			name:    "Destructured Object",
 			path:    "destructured_object",
-			summary: `let destructured_object: { name: string; age: number };`,
+			summary: "Complex variable declarations with types",
 			source: `// Complex variable declarations with types
 let destructured_object: { name: string; age: number };`,
 			startLine: 46,
@@ -1776,7 +1808,7 @@ let destructured_object: { name: string; age: number };`,
 		{
 			name:      "Destructured Array",
 			path:      "destructured_array",
-			summary:   `const destructured_array: [number, ...number[]] = [1, 2, 3];`,
+			summary:   "destructured array: const destructured_array: [number, ...number[]] = [1, 2, 3];",
 			source:    `const destructured_array: [number, ...number[]] = [1, 2, 3];`,
 			startLine: 48,
 			endLine:   48,
@@ -1784,7 +1816,7 @@ let destructured_object: { name: string; age: number };`,
 		{
 			name:      "Union Type",
 			path:      "union_type",
-			summary:   `let union_type: string | number = "could be either";`,
+			summary:   `union type: let union_type: string | number = "could be either";`,
 			source:    `let union_type: string | number = "could be either";`,
 			startLine: 49,
 			endLine:   49,
@@ -1792,7 +1824,7 @@ let destructured_object: { name: string; age: number };`,
 		{
 			name:      "Optional Type",
 			path:      "optional_type",
-			summary:   `let optional_type: string | undefined;`,
+			summary:   "optional type: let optional_type: string | undefined;",
 			source:    `let optional_type: string | undefined;`,
 			startLine: 50,
 			endLine:   50,
@@ -1800,7 +1832,7 @@ let destructured_object: { name: string; age: number };`,
 		{
 			name:    "Generic Array",
 			path:    "generic_array",
-			summary: `let generic_array: Array<string> = ["one", "two"];`,
+			summary: "Generic variable",
 			source: `// Generic variable
 let generic_array: Array<string> = ["one", "two"];`,
 			startLine: 52,
@@ -1809,7 +1841,7 @@ let generic_array: Array<string> = ["one", "two"];`,
 		{
 			name:      "Record Type",
 			path:      "record_type",
-			summary:   `let record_type: Record<string, number> = { a: 1, b: 2 };`,
+			summary:   "record type: let record_type: Record<string, number> = { a: 1, b: 2 };",
 			source:    `let record_type: Record<string, number> = { a: 1, b: 2 };`,
 			startLine: 54,
 			endLine:   54,
@@ -1817,7 +1849,7 @@ let generic_array: Array<string> = ["one", "two"];`,
 		{
 			name:    "Class Instance",
 			path:    "class_instance",
-			summary: `const class_instance: Date = new Date();`,
+			summary: "Class instance with type",
 			source: `// Class instance with type
 const class_instance: Date = new Date();`,
 			startLine: 56,
@@ -1826,7 +1858,7 @@ const class_instance: Date = new Date();`,
 		{
 			name:    "Assertion Var",
 			path:    "assertion_var",
-			summary: `let assertion_var = "hello" as string;`,
+			summary: "Type assertions",
 			source: `// Type assertions
 let assertion_var = "hello" as string;`,
 			startLine: 59,
@@ -1835,7 +1867,7 @@ let assertion_var = "hello" as string;`,
 		{
 			name:      "Angle Bracket Assertion",
 			path:      "angle_bracket_assertion",
-			summary:   `let angle_bracket_assertion = <number>42;`,
+			summary:   "angle bracket assertion: let angle_bracket_assertion = <number>42;",
 			source:    `let angle_bracket_assertion = <number>42;`,
 			startLine: 61,
 			endLine:   61,
@@ -1843,7 +1875,7 @@ let assertion_var = "hello" as string;`,
 		{
 			name:    "Readonly Array",
 			path:    "readonly_array",
-			summary: `const readonly_array = [1, 2, 3] as const;`,
+			summary: "Readonly and const assertions",
 			source: `// Readonly and const assertions
 const readonly_array = [1, 2, 3] as const;`,
 			startLine: 63,
@@ -1852,7 +1884,7 @@ const readonly_array = [1, 2, 3] as const;`,
 		{
 			name:      "Readonly Object",
 			path:      "readonly_object",
-			summary:   `let readonly_object: Readonly<{ x: number }> = { x: 10 };`,
+			summary:   "readonly object: let readonly_object: Readonly<{ x: number }> = { x: 10 };",
 			source:    `let readonly_object: Readonly<{ x: number }> = { x: 10 };`,
 			startLine: 65,
 			endLine:   65,
@@ -1860,7 +1892,7 @@ const readonly_array = [1, 2, 3] as const;`,
 		{
 			name:      "Ambient Declaration",
 			path:      "jQuery",
-			summary:   `declare var jQuery: any;`,
+			summary:   "j query: declare var jQuery: any;",
 			source:    `declare var jQuery: any;`,
 			startLine: 67,
 			endLine:   67,
@@ -1868,7 +1900,7 @@ const readonly_array = [1, 2, 3] as const;`,
 		{
 			name:    "Exported Const",
 			path:    "EXPORTED_CONST",
-			summary: `const EXPORTED_CONST = "constant value";`,
+			summary: "Exported variables",
 			source: `// Exported variables
 export const EXPORTED_CONST = "constant value";`,
 			startLine: 69,
@@ -1877,7 +1909,7 @@ export const EXPORTED_CONST = "constant value";`,
 		{
 			name:      "Exported VIEW_TYPE_REVIEW",
 			path:      "VIEW_TYPE_REVIEW",
-			summary:   `const VIEW_TYPE_REVIEW = "tutor-review";`,
+			summary:   `view type review: const VIEW_TYPE_REVIEW = "tutor-review";`,
 			source:    `export const VIEW_TYPE_REVIEW = "tutor-review";`,
 			startLine: 72,
 			endLine:   72,
@@ -1885,7 +1917,7 @@ export const EXPORTED_CONST = "constant value";`,
 		{
 			name:      "Exported Let",
 			path:      "exportedLet",
-			summary:   `let exportedLet: number = 42;`,
+			summary:   "exported let: let exportedLet: number = 42;",
 			source:    `export let exportedLet: number = 42;`,
 			startLine: 74,
 			endLine:   74,
@@ -1909,6 +1941,42 @@ export const EXPORTED_CONST = "constant value";`,
 	}
 }
 
+func TestTSXParsing(t *testing.T) {
+	p, err := parser.NewTSXParser(filepath.Join("..", "..", "testdata"))
+	require.NoError(t, err)
+	defer p.Close()
+
+	file, err := p.Chunk("typescript/components.tsx")
+	require.NoError(t, err)
+
+	kinds := map[string]string{}
+	for _, chunk := range file.Chunks {
+		kinds[chunk.Path] = chunk.Kind
+		assert.Equal(t, "typescript", chunk.Language)
+	}
+
+	tests := []struct {
+		path string
+		kind string
+	}{
+		{path: "CounterProps", kind: "type_alias_declaration"},
+		{path: "Counter", kind: "lexical_declaration component"},
+		{path: "Badge", kind: "lexical_declaration component"},
+		{path: "useToggle", kind: "function_declaration hook"},
+		{path: "Page", kind: "class_declaration component"},
+		{path: "Page::render", kind: "method_definition"},
+		{path: "formatCount", kind: "function_declaration"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			kind, exists := kinds[test.path]
+			require.True(t, exists, "chunk %s not found", test.path)
+			assert.Equal(t, test.kind, kind)
+		})
+	}
+}
+
 func TestTypeScriptParserTestSuite(t *testing.T) {
 	suite.Run(t, new(TypeScriptParserTestSuite))
 }
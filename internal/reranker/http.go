@@ -0,0 +1,149 @@
+package reranker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+)
+
+const (
+	// maxDocumentChars bounds how much of each candidate is sent for reranking
+	maxDocumentChars = 4000
+	requestTimeout   = 30 * time.Second
+)
+
+// HTTP reranks candidates using a cross-encoder behind a rerank endpoint,
+// e.g., one served by llama.cpp, vLLM, or Ollama
+type HTTP struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewHTTP creates a reranker for the API at baseURL, e.g., http://localhost:8080/v1.
+// The API key is optional for local endpoints.
+func NewHTTP(baseURL, model, apiKey string) *HTTP {
+	return &HTTP{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		model:   model,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: requestTimeout},
+	}
+}
+
+type rerankRequest struct {
+	Model     string   `json:"model,omitempty"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n"`
+}
+
+type rerankResponse struct {
+	Results []struct {
+		Index          int      `json:"index"`
+		RelevanceScore *float64 `json:"relevance_score"`
+		Score          *float64 `json:"score"`
+	} `json:"results"`
+}
+
+func (h *HTTP) Rerank(ctx context.Context, query string, candidates []index.SearchResult) ([]index.SearchResult, error) {
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	documents := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		chunk := candidate.Chunk
+		document := fmt.Sprintf("%s\n%s\n\n%s", chunk.ID(), chunk.Summary, chunk.Source)
+		if len(document) > maxDocumentChars {
+			document = document[:maxDocumentChars]
+		}
+		documents[i] = document
+	}
+
+	body, err := json.Marshal(rerankRequest{
+		Model:     h.model,
+		Query:     query,
+		Documents: documents,
+		TopN:      len(documents),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+"/rerank", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rerank: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to rerank: %s", resp.Status)
+	}
+
+	var ranking rerankResponse
+	err = json.NewDecoder(resp.Body).Decode(&ranking)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode reranking: %w", err)
+	}
+
+	type scored struct {
+		index int
+		score float64
+	}
+
+	ranked := make([]scored, 0, len(ranking.Results))
+	for _, result := range ranking.Results {
+		if result.Index < 0 || result.Index >= len(candidates) {
+			return nil, fmt.Errorf("reranking returned unknown document %d", result.Index)
+		}
+
+		switch {
+		case result.RelevanceScore != nil:
+			ranked = append(ranked, scored{result.Index, *result.RelevanceScore})
+		case result.Score != nil:
+			ranked = append(ranked, scored{result.Index, *result.Score})
+		}
+	}
+
+	// Results are usually sorted already, but not all servers do so
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	// Candidates the endpoint didn't score keep their order, after the scored ones
+	reranked := make([]index.SearchResult, 0, len(candidates))
+	seen := make([]bool, len(candidates))
+	for _, s := range ranked {
+		if !seen[s.index] {
+			seen[s.index] = true
+			reranked = append(reranked, candidates[s.index])
+		}
+	}
+
+	for i, candidate := range candidates {
+		if !seen[i] {
+			reranked = append(reranked, candidate)
+		}
+	}
+
+	return reranked, nil
+}
@@ -0,0 +1,171 @@
+package reranker
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+// Reranker reorders search candidates so that the most relevant come first
+type Reranker interface {
+	Rerank(ctx context.Context, query string, candidates []index.SearchResult) ([]index.SearchResult, error)
+}
+
+const (
+	exactNameBoost  = 0.3  // query mentions the chunk's identifier verbatim
+	nameWordsBoost  = 0.1  // scaled by the share of the identifier's words in the query
+	fileTypeBoost   = 0.03 // source by default, tests or docs if the query asks for them
+	definitionBoost = 0.03 // functions, methods, classes & types
+	minorKindBoost  = -0.05
+	recencyBoost    = 0.03 // decays with the file's age
+	recencyHalfLife = 7 * 24 * time.Hour
+)
+
+// definitionKinds & minorKinds match chunk kinds, e.g., tree-sitter node kinds, by substring
+var (
+	definitionKinds = []string{"function", "method", "class", "interface", "type", "enum", "module", "component", "hook"}
+	minorKinds      = []string{"comment", "import", "package", "field"}
+)
+
+// fileTypeWords hint at the file type a query asks for, in order of precedence
+var fileTypeWords = []struct {
+	fileType string
+	words    []string
+}{
+	{fileType: "tests", words: []string{"test", "tests", "testing", "spec", "specs"}},
+	{fileType: "docs", words: []string{"doc", "docs", "documentation", "readme", "guide"}},
+}
+
+// Heuristic reranks candidates by adjusting their similarity with cheap signals:
+// identifier hits, file type priors, symbol kinds & how recently files changed
+type Heuristic struct {
+	workspaceRoot string
+	now           func() time.Time
+}
+
+// NewHeuristic creates a heuristic reranker for the workspace at workspaceRoot
+func NewHeuristic(workspaceRoot string) *Heuristic {
+	return &Heuristic{workspaceRoot: workspaceRoot, now: time.Now}
+}
+
+func (h *Heuristic) Rerank(ctx context.Context, query string, candidates []index.SearchResult) ([]index.SearchResult, error) {
+	terms := queryTerms(query)
+	words := map[string]bool{}
+	for _, term := range terms {
+		for _, word := range parser.SplitIdentifier(term) {
+			words[parser.Stem(word)] = true
+		}
+	}
+
+	fileType := queryFileType(words)
+
+	modTimes := map[string]time.Time{}
+	scores := make(map[*parser.Chunk]float64, len(candidates))
+	for _, candidate := range candidates {
+		chunk := candidate.Chunk
+		score := float64(candidate.Similarity)
+		score += nameScore(chunk.Name, terms, words)
+
+		if chunk.Type == fileType {
+			score += fileTypeBoost
+		}
+
+		switch {
+		case containsAny(chunk.Kind, minorKinds):
+			score += minorKindBoost
+		case containsAny(chunk.Kind, definitionKinds):
+			score += definitionBoost
+		}
+
+		modTime, ok := modTimes[chunk.File]
+		if !ok {
+			info, err := os.Stat(filepath.Join(h.workspaceRoot, chunk.File))
+			if err == nil {
+				modTime = info.ModTime()
+			}
+			modTimes[chunk.File] = modTime
+		}
+
+		if !modTime.IsZero() {
+			age := max(h.now().Sub(modTime), 0)
+			score += recencyBoost * math.Exp2(-float64(age)/float64(recencyHalfLife))
+		}
+
+		scores[chunk] = score
+	}
+
+	reranked := make([]index.SearchResult, len(candidates))
+	copy(reranked, candidates)
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return scores[reranked[i].Chunk] > scores[reranked[j].Chunk]
+	})
+
+	return reranked, nil
+}
+
+// nameScore boosts chunks whose identifier is in the query, either verbatim
+// (e.g., FindUser) or as words (e.g., "find the user")
+// queryFileType returns the file type a query asks for, src unless it mentions tests or docs
+func queryFileType(words map[string]bool) string {
+	for _, hint := range fileTypeWords {
+		for _, word := range hint.words {
+			if words[parser.Stem(word)] {
+				return hint.fileType
+			}
+		}
+	}
+
+	return "src"
+}
+
+func nameScore(name string, terms []string, words map[string]bool) float64 {
+	if name == "" {
+		return 0
+	}
+
+	for _, term := range terms {
+		if term == name {
+			return exactNameBoost
+		}
+	}
+
+	nameWords := parser.SplitIdentifier(name)
+	if len(nameWords) == 0 {
+		return 0
+	}
+
+	var hits int
+	for _, word := range nameWords {
+		if words[parser.Stem(word)] {
+			hits++
+		}
+	}
+
+	return nameWordsBoost * float64(hits) / float64(len(nameWords))
+}
+
+// queryTerms splits a query into identifier-like terms, e.g., "where is Service.FindUser called"
+// -> where, is, Service, FindUser, called
+func queryTerms(query string) []string {
+	return strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,174 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/index"
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func candidate(file, path, name, kind, fileType string, similarity float32) index.SearchResult {
+	return index.SearchResult{
+		Chunk: &parser.Chunk{
+			File: file, Path: path, Name: name, Kind: kind, Type: fileType, Source: name,
+		},
+		Similarity: similarity,
+	}
+}
+
+func ids(results []index.SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.Chunk.ID()
+	}
+
+	return ids
+}
+
+func TestHeuristic(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		candidates []index.SearchResult
+		expected   []string
+	}{
+		{
+			name:  "ExactIdentifier",
+			query: "where is FindUser called",
+			candidates: []index.SearchResult{
+				candidate("users.go", "Service::AddUser", "AddUser", "method_declaration", "src", 0.62),
+				candidate("users.go", "Service::FindUser", "FindUser", "method_declaration", "src", 0.55),
+			},
+			expected: []string{"users.go::Service::FindUser", "users.go::Service::AddUser"},
+		},
+		{
+			name:  "IdentifierWords",
+			query: "creates default users",
+			candidates: []index.SearchResult{
+				candidate("users.go", "NewService", "NewService", "function_declaration", "src", 0.52),
+				candidate("users.go", "createDefaultUser", "createDefaultUser", "function_declaration", "src", 0.48),
+			},
+			expected: []string{"users.go::createDefaultUser", "users.go::NewService"},
+		},
+		{
+			name:  "PreferSource",
+			query: "parse the config",
+			candidates: []index.SearchResult{
+				candidate("config_test.go", "TestParse", "TestParse", "function_declaration", "tests", 0.50),
+				candidate("config.go", "Parse", "Parse", "function_declaration", "src", 0.49),
+			},
+			expected: []string{"config.go::Parse", "config_test.go::TestParse"},
+		},
+		{
+			name:  "PreferTestsWhenAsked",
+			query: "tests for parsing the config",
+			candidates: []index.SearchResult{
+				candidate("config.go", "Load", "Load", "function_declaration", "src", 0.50),
+				candidate("config_test.go", "TestLoad", "TestLoad", "function_declaration", "tests", 0.49),
+			},
+			expected: []string{"config_test.go::TestLoad", "config.go::Load"},
+		},
+		{
+			name:  "PreferTestsOverDocs",
+			query: "docs & tests for parsing the config",
+			candidates: []index.SearchResult{
+				candidate("docs/config.md", "Parsing", "Parsing", "section", "docs", 0.50),
+				candidate("config_test.go", "TestLoad", "TestLoad", "function_declaration", "tests", 0.49),
+			},
+			expected: []string{"config_test.go::TestLoad", "docs/config.md::Parsing"},
+		},
+		{
+			name:  "DemoteComments",
+			query: "config loading",
+			candidates: []index.SearchResult{
+				candidate("config.go", "5e1c0a3f", "", "comment", "src", 0.50),
+				candidate("config.go", "defaults", "defaults", "var_declaration", "src", 0.47),
+			},
+			expected: []string{"config.go::defaults", "config.go::5e1c0a3f"},
+		},
+		{
+			name:  "KeepSimilarityOrder",
+			query: "something unrelated",
+			candidates: []index.SearchResult{
+				candidate("a.go", "A", "A", "function_declaration", "src", 0.6),
+				candidate("b.go", "B", "B", "function_declaration", "src", 0.5),
+			},
+			expected: []string{"a.go::A", "b.go::B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reranker := NewHeuristic(t.TempDir())
+			reranked, err := reranker.Rerank(context.Background(), tt.query, tt.candidates)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ids(reranked))
+		})
+	}
+}
+
+func TestHeuristicRecency(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"old.go", "new.go"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), nil, 0o644))
+	}
+
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "old.go"), old, old))
+
+	reranked, err := NewHeuristic(root).Rerank(context.Background(), "incoming traffic", []index.SearchResult{
+		candidate("old.go", "Handle", "Handle", "function_declaration", "src", 0.50),
+		candidate("new.go", "Serve", "Serve", "function_declaration", "src", 0.49),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new.go::Serve", "old.go::Handle"}, ids(reranked))
+}
+
+func TestHTTP(t *testing.T) {
+	var request rerankRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/rerank", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		// Unsorted, using Ollama-style scores & leaving out the last document
+		w.Write([]byte(`{"results": [{"index": 0, "score": 0.1}, {"index": 1, "score": 0.9}]}`))
+	}))
+	defer server.Close()
+
+	candidates := []index.SearchResult{
+		candidate("a.go", "A", "A", "function_declaration", "src", 0.7),
+		candidate("b.go", "B", "B", "function_declaration", "src", 0.6),
+		candidate("c.go", "C", "C", "function_declaration", "src", 0.5),
+	}
+
+	reranked, err := NewHTTP(server.URL+"/v1/", "reranker", "secret").Rerank(context.Background(), "b", candidates)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b.go::B", "a.go::A", "c.go::C"}, ids(reranked))
+
+	assert.Equal(t, "reranker", request.Model)
+	assert.Equal(t, "b", request.Query)
+	assert.Len(t, request.Documents, 3)
+	assert.Contains(t, request.Documents[1], "b.go::B")
+}
+
+func TestHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTP(server.URL, "", "").Rerank(context.Background(), "query", []index.SearchResult{
+		candidate("a.go", "A", "A", "function_declaration", "src", 0.7),
+	})
+	assert.ErrorContains(t, err, "503")
+}
@@ -0,0 +1,114 @@
+package summarizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/st3v3nmw/sourcerer-mcp/internal/parser"
+)
+
+const (
+	// maxSourceChars bounds how much of a chunk is sent for description
+	maxSourceChars = 8000
+	requestTimeout = 60 * time.Second
+)
+
+const systemPrompt = `You describe code for a semantic code search index.
+In one or two sentences, describe what the given code does and why someone would look for it.
+Focus on purpose & behavior rather than syntax. Don't repeat the code or its name verbatim.`
+
+// Summarizer describes chunks in natural language, e.g., using an LLM
+type Summarizer interface {
+	Describe(ctx context.Context, chunk *parser.Chunk) (string, error)
+}
+
+// OpenAI describes chunks using an OpenAI-compatible chat completions endpoint,
+// e.g., a local model served by Ollama or llama.cpp
+type OpenAI struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewOpenAI creates a summarizer for the API at baseURL, e.g., http://localhost:11434/v1.
+// The API key is optional for local endpoints.
+func NewOpenAI(baseURL, model, apiKey string) *OpenAI {
+	return &OpenAI{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		model:   model,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: requestTimeout},
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (o *OpenAI) Describe(ctx context.Context, chunk *parser.Chunk) (string, error) {
+	source := chunk.Source
+	if len(source) > maxSourceChars {
+		source = source[:maxSourceChars]
+	}
+
+	body, err := json.Marshal(chatRequest{
+		Model: o.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: fmt.Sprintf("File: %s\nLanguage: %s\n\n%s", chunk.File, chunk.Language, source)},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe %s: %w", chunk.ID(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to describe %s: %s", chunk.ID(), resp.Status)
+	}
+
+	var completion chatResponse
+	err = json.NewDecoder(resp.Body).Decode(&completion)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode description of %s: %w", chunk.ID(), err)
+	}
+
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("no description returned for %s", chunk.ID())
+	}
+
+	return strings.TrimSpace(completion.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,69 @@
+package testdata
+
+import "fmt"
+
+// Level is a logging level
+type Level int
+
+// Logging levels
+const (
+	// LevelDebug is for verbose output
+	LevelDebug Level = iota
+	LevelInfo
+	LevelError
+)
+
+const (
+	ModeRead = 1 << iota
+	ModeWrite
+)
+
+// Limits for requests
+const (
+	// MaxRequests caps concurrent requests
+	MaxRequests = 10
+	MaxRetries  = 3
+)
+
+var (
+	defaultName = "anonymous"
+	// ready is set once the server starts
+	ready bool
+)
+
+type (
+	// Point is a position on a grid
+	Point struct {
+		// X is the horizontal position
+		X, Y  int
+		Label string
+		fmt.Stringer
+		*Level
+		Meta struct {
+			Tags []string
+		}
+	}
+
+	// Shape is anything with an area
+	Shape interface {
+		// Area computes the shape's area
+		Area() float64
+		Perimeter() float64
+		fmt.Stringer
+	}
+
+	Alias = Point
+)
+
+// Number constrains numeric types
+type Number interface {
+	~int | ~float64
+}
+
+// Origin of the grid
+var originX, originY = 0, 0
+
+var handler = func() {
+	var inner = 1
+	_ = inner
+}
@@ -0,0 +1,31 @@
+// API client for the users service
+const api = {
+    // Fetches a user by ID
+    getUser(id) {
+        return fetch(`/users/${id}`);
+    },
+    deleteUser: async (id) => {
+        return fetch(`/users/${id}`, { method: "DELETE" });
+    },
+    baseURL: "/users",
+};
+
+export const handler = async (event) => {
+    const user = await api.getUser(event.id);
+    return { statusCode: 200, body: JSON.stringify(user) };
+};
+
+function formatUser(user) {
+    const labels = { short() { return user.name; } };
+    return labels.short();
+}
+
+module.exports = {
+    formatUser,
+    parseUser: (raw) => JSON.parse(raw),
+    service: "users",
+};
+
+exports.version = function () {
+    return "1.0";
+};
@@ -31,3 +31,8 @@ class InheritedClass(ClassWithMethods):
 class DecoratedClass:
     name: str
     age: int
+
+class UndocumentedClass:
+    def method(self):
+        """Return one."""
+        return 1
@@ -33,3 +33,10 @@ async def async_function():
 def generator_function():
     yield 1
     yield 2
+
+def documented_function(x):
+    """Double x.
+
+    Multi-line docstrings keep their paragraphs.
+    """
+    return x * 2
@@ -0,0 +1,5 @@
+"""Shop domain models & helpers."""
+
+from .models import Product, Price as Amount
+from . import pricing
+from .pricing import *
@@ -0,0 +1,58 @@
+#!/usr/bin/env python3
+"""Products sold by the shop."""
+
+from typing import TypeAlias, overload
+
+type Sku = str
+Tags: TypeAlias = list[str]
+
+
+class Price:
+    """An amount in cents."""
+
+    def __init__(self, cents):
+        self.cents = cents
+
+
+class Product:
+    """A product in the catalog."""
+
+    def __init__(self, sku: Sku, name: str):
+        self._sku = sku
+        self._name = name
+
+    @property
+    def name(self):
+        """The product's display name."""
+        return self._name
+
+    @name.setter
+    def name(self, value):
+        self._name = value.strip()
+
+    @staticmethod
+    def parse(raw: str) -> "Product":
+        sku, name = raw.split(":")
+        return Product(sku, name)
+
+    @overload
+    def discount(self, percent: int) -> int: ...
+
+    @overload
+    def discount(self, percent: float) -> float: ...
+
+    def discount(self, percent):
+        return percent
+
+
+async def load_catalog(lines):
+    """Loads products from raw catalog lines."""
+
+    def parse_line(line):
+        return Product.parse(line.strip())
+
+    return [parse_line(line) for line in lines]
+
+
+if __name__ == "__main__":
+    print(Product.parse("1:Tea").name)
@@ -0,0 +1,6 @@
+"""Price calculations."""
+
+
+def apply_tax(cents, rate):
+    """Adds tax to a price."""
+    return round(cents * (1 + rate))
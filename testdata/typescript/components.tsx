@@ -0,0 +1,26 @@
+import React, { memo, useState } from "react";
+
+type CounterProps = { label: string };
+
+// Counts clicks on a button
+export const Counter = ({ label }: CounterProps) => {
+    const [count, setCount] = useState<number>(0);
+    return <button onClick={() => setCount(count + 1)}>{label}: {count}</button>;
+};
+
+export const Badge = memo(({ text }: { text: string }) => <span>{text}</span>);
+
+export function useToggle(initial = false) {
+    const [on, setOn] = useState(initial);
+    return [on, () => setOn(!on)] as const;
+}
+
+export default class Page extends React.Component<CounterProps> {
+    render() {
+        return <Counter label={this.props.label} />;
+    }
+}
+
+export function formatCount(count: number): string {
+    return `${count} clicks`;
+}